@@ -0,0 +1,3 @@
+data "powerdns_autoprimaries" "all" {
+  server_id = "localhost"
+}
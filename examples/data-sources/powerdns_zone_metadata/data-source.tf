@@ -0,0 +1,5 @@
+data "powerdns_zone_metadata" "example_net_also_notify" {
+  server_id = "localhost"
+  zone_id   = "example.net."
+  kind      = "ALSO-NOTIFY"
+}
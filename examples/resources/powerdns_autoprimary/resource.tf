@@ -0,0 +1,6 @@
+resource "powerdns_autoprimary" "primary" {
+  server_id  = "localhost"
+  ip         = "192.0.2.1"
+  nameserver = "ns1.example.com"
+  account    = "example"
+}
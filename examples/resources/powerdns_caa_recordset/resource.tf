@@ -0,0 +1,10 @@
+resource "powerdns_caa_recordset" "example_com" {
+  server_id = "localhost"
+  zone_id   = "example.com."
+  name      = "example.com."
+  ttl       = 3600
+  records = [
+    { flags = 0, tag = "issue", value = "letsencrypt.org" },
+    { flags = 0, tag = "iodef", value = "mailto:security@example.com" },
+  ]
+}
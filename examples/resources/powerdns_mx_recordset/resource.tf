@@ -0,0 +1,10 @@
+resource "powerdns_mx_recordset" "example_com" {
+  server_id = "localhost"
+  zone_id   = "example.com."
+  name      = "example.com."
+  ttl       = 3600
+  records = [
+    { priority = 10, exchange = "mail1.example.com." },
+    { priority = 20, exchange = "mail2.example.com." },
+  ]
+}
@@ -0,0 +1,20 @@
+# Several services register under the same name. Each record is managed on
+# its own, the other records of the record set are kept.
+resource "powerdns_record" "api_blue" {
+  zone_id   = "example.com."
+  server_id = "localhost"
+  name      = "api.example.com."
+  type      = "A"
+  ttl       = 300
+  content   = "192.0.2.10"
+}
+
+resource "powerdns_record" "api_green" {
+  zone_id   = "example.com."
+  server_id = "localhost"
+  name      = "api.example.com."
+  type      = "A"
+  ttl       = 300
+  content   = "192.0.2.20"
+  disabled  = true
+}
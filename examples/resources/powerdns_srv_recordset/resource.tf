@@ -0,0 +1,10 @@
+resource "powerdns_srv_recordset" "sip" {
+  server_id = "localhost"
+  zone_id   = "example.com."
+  name      = "_sip._tcp.example.com."
+  ttl       = 3600
+  records = [
+    { priority = 10, weight = 60, port = 5060, target = "sip1.example.com." },
+    { priority = 10, weight = 40, port = 5060, target = "sip2.example.com." },
+  ]
+}
@@ -0,0 +1,16 @@
+# Let the server generate the secret, which is stored in the state.
+resource "powerdns_tsigkey" "axfr" {
+  server_id = "localhost"
+  name      = "axfr-key"
+  algorithm = "hmac-sha256"
+}
+
+# Supply the secret as write-only attribute, so it never lands in the state.
+# Increment key_wo_version to rotate the secret.
+resource "powerdns_tsigkey" "dnsupdate" {
+  server_id      = "localhost"
+  name           = "dnsupdate-key"
+  algorithm      = "hmac-sha256"
+  key_wo         = var.dnsupdate_tsig_secret
+  key_wo_version = 1
+}
@@ -0,0 +1,10 @@
+resource "powerdns_txt_recordset" "dkim" {
+  server_id = "localhost"
+  zone_id   = "example.com."
+  name      = "mail._domainkey.example.com."
+  ttl       = 3600
+  # Quoting and splitting into strings of 255 bytes is done by the provider.
+  records = [
+    "v=DKIM1; k=rsa; p=${var.dkim_public_key}",
+  ]
+}
@@ -2,4 +2,11 @@ resource "powerdns_zone" "example_org" {
   name      = "example.org."
   server_id = "localhost"
   kind      = "Native"
+
+  nameservers = [
+    "ns1.example.org.",
+    "ns2.example.org.",
+  ]
+  soa_edit_api = "DEFAULT"
+  api_rectify  = true
 }
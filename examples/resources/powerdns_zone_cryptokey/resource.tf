@@ -0,0 +1,17 @@
+resource "powerdns_zone" "example_org" {
+  name      = "example.org."
+  server_id = "localhost"
+  kind      = "Native"
+}
+
+resource "powerdns_zone_cryptokey" "example_org_csk" {
+  server_id = powerdns_zone.example_org.server_id
+  zone_id   = powerdns_zone.example_org.id
+  keytype   = "csk"
+  algorithm = "ECDSAP256SHA256"
+  active    = true
+}
+
+output "example_org_ds" {
+  value = powerdns_zone_cryptokey.example_org_csk.ds
+}
@@ -0,0 +1,12 @@
+resource "powerdns_zone" "example_org" {
+  name      = "example.org."
+  server_id = "localhost"
+  kind      = "Master"
+}
+
+resource "powerdns_zone_metadata" "example_org_allow_axfr" {
+  server_id = powerdns_zone.example_org.server_id
+  zone_id   = powerdns_zone.example_org.id
+  kind      = "ALLOW-AXFR-FROM"
+  values    = ["192.0.2.0/24", "2001:db8::/32"]
+}
@@ -1,17 +1,57 @@
 package powerdns
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
 )
 
 type Client struct {
-	client pdnsclient.ClientWithResponsesInterface
+	client     pdnsclient.ClientWithResponsesInterface
+	zoneLocks  keyedLocks
+	rrsetLocks keyedLocks
+	batcher    rrsetBatcher
+	serverID   string
+	tls        *TLSOptions
+	retry      retryOptions
+	limits     limitOptions
+}
+
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// WithBatchWindow enables batching of record set changes. Changes for the
+// same zone that arrive within window of the first one are sent to the server
+// in a single PATCH request. A window of zero disables batching.
+func WithBatchWindow(window time.Duration) Option {
+	return func(c *Client) {
+		c.batcher.window = window
+	}
+}
+
+// WithServerID sets the server id returned by ServerID.
+func WithServerID(serverID string) Option {
+	return func(c *Client) {
+		c.serverID = serverID
+	}
+}
+
+// ServerID returns the id of the server to use when none is given
+// explicitly. PowerDNS itself only knows the server "localhost", which is
+// returned unless WithServerID was used.
+func (pdns *Client) ServerID() string {
+	if pdns.serverID == "" {
+		return "localhost"
+	}
+	return pdns.serverID
 }
 
 // apiResponse is implemented by all generated *Response types and lets
@@ -19,6 +59,7 @@ type Client struct {
 // duplicating logic per endpoint.
 type apiResponse interface {
 	StatusCode() int
+	GetBody() []byte
 	GetJSONDefault() *pdnsclient.ErrorResponse
 }
 
@@ -30,20 +71,96 @@ func checkResponse(resp apiResponse, wantStatus int) error {
 	if resp.StatusCode() == wantStatus {
 		return nil
 	}
-	if errResp := resp.GetJSONDefault(); errResp != nil {
+	errResp := resp.GetJSONDefault()
+	if errResp == nil {
+		// Error bodies of explicitly documented status codes (e.g. 409) are
+		// not parsed into JSONDefault.
+		var e pdnsclient.ErrorResponse
+		if err := json.Unmarshal(resp.GetBody(), &e); err == nil && e.Error != "" {
+			errResp = &e
+		}
+	}
+	if isNotFoundResponse(resp.StatusCode(), errResp) {
+		msg := "not found"
+		if errResp != nil {
+			msg = errResp.Error
+		}
+		return &NotFoundError{msg: fmt.Sprintf("powerdns api error (status %d): %s", resp.StatusCode(), msg)}
+	}
+	if errResp != nil {
 		return fmt.Errorf("powerdns api error (status %d): %s", resp.StatusCode(), errResp.Error)
 	}
 	return fmt.Errorf("powerdns api error: unexpected status %d", resp.StatusCode())
 }
 
+// isNotFoundResponse reports whether the API signalled that the requested
+// object does not exist. Older PowerDNS versions answer requests for unknown
+// zones with 422 "Could not find domain" instead of 404.
+func isNotFoundResponse(status int, errResp *pdnsclient.ErrorResponse) bool {
+	switch status {
+	case http.StatusNotFound:
+		return true
+	case http.StatusUnprocessableEntity:
+		return errResp != nil && strings.Contains(errResp.Error, "Could not find domain")
+	default:
+		return false
+	}
+}
+
+// withJSONBody returns a request editor that sets the JSON encoding of v as
+// request body. It is needed for operations whose request body is missing in
+// the OpenAPI spec of PowerDNS.
+func withJSONBody(v any) (pdnsclient.RequestEditorFn, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(_ context.Context, req *http.Request) error {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Type", "application/json")
+		return nil
+	}, nil
+}
+
+// NotFoundError is returned when a zone or record set does not exist on the
+// server. Use IsNotFound to detect it.
+type NotFoundError struct {
+	msg string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.msg
+}
+
+// IsNotFound reports whether err (or any error it wraps) is a NotFoundError.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound)
+}
+
 type Zone struct {
-	ID         string
-	Name       string
-	Kind       string
-	DNSSec     bool
-	Serial     int64
-	Masters    []string
-	RecordSets []RecordSet
+	ID          string
+	Name        string
+	Kind        string
+	DNSSec      bool
+	Serial      int64
+	Masters     []string
+	Nameservers []string
+	RecordSets  []RecordSet
+
+	// Optional zone settings. A nil value is not sent to the server, so the
+	// server side default (or the current value on update) is kept.
+	SoaEdit    *string
+	SoaEditAPI *string
+	Account    *string
+	Catalog    *string
+	APIRectify *bool
+	Presigned  *bool
 }
 
 type RecordSet struct {
@@ -51,10 +168,26 @@ type RecordSet struct {
 	Type       string
 	TTL        int64
 	Changetype string
-	Records    []string
+	Records    []Record
+
+	// Comments of the record set. A nil slice is not sent to the server, so
+	// existing comments are kept when the record set is replaced. An empty
+	// slice removes all comments.
+	Comments []Comment
+}
+
+type Record struct {
+	Content  string
+	Disabled bool
+}
+
+type Comment struct {
+	Content    string
+	Account    string
+	ModifiedAt int64
 }
 
-func New(ctx context.Context, apiKey, serverHost, basePath, scheme string) (*Client, error) {
+func New(ctx context.Context, apiKey, serverHost, basePath, scheme string, opts ...Option) (*Client, error) {
 	baseURL := fmt.Sprintf("%s://%s%s", scheme, serverHost, basePath)
 
 	authEditor := func(_ context.Context, req *http.Request) error {
@@ -62,14 +195,39 @@ func New(ctx context.Context, apiKey, serverHost, basePath, scheme string) (*Cli
 		return nil
 	}
 
+	pdns := &Client{}
+	for _, opt := range opts {
+		opt(pdns)
+	}
+
 	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if pdns.tls != nil {
+		tlsConfig, err := pdns.tls.config()
+		if err != nil {
+			return nil, fmt.Errorf("configuring TLS: %w", err)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		httpClient.Transport = transport
+	}
 
-	client, err := pdnsclient.NewClientWithResponses(baseURL, pdnsclient.WithHTTPClient(httpClient), pdnsclient.WithRequestEditorFn(authEditor))
+	// Limits apply to every attempt of a request, so retries are throttled
+	// like any other request.
+	var doer pdnsclient.HttpRequestDoer = httpClient
+	if pdns.limits.enabled() {
+		doer = newLimitDoer(doer, pdns.limits)
+	}
+	if pdns.retry.maxRetries > 0 {
+		doer = &retryDoer{doer: doer, retryOptions: pdns.retry}
+	}
+
+	client, err := pdnsclient.NewClientWithResponses(baseURL, pdnsclient.WithHTTPClient(doer), pdnsclient.WithRequestEditorFn(authEditor))
 	if err != nil {
 		return nil, fmt.Errorf("creating powerdns client: %w", err)
 	}
+	pdns.client = client
 
-	return &Client{client: client}, nil
+	return pdns, nil
 }
 
 func (pdns *Client) CreateZone(ctx context.Context, serverID string, zone *Zone) (*Zone, error) {
@@ -130,13 +288,7 @@ func (pdns *Client) CreateRecordSet(ctx context.Context, serverID, zoneID string
 	changeTypeReplace := "REPLACE"
 	rrset.Changetype = &changeTypeReplace
 
-	zone := pdnsclient.Zone{Rrsets: &[]pdnsclient.RRSet{rrset}}
-
-	resp, err := pdns.client.PatchZoneWithResponse(ctx, serverID, zoneID, zone)
-	if err != nil {
-		return nil, err
-	}
-	if err := checkResponse(resp, http.StatusNoContent); err != nil {
+	if err := pdns.patchZone(ctx, serverID, zoneID, rrset); err != nil {
 		return nil, err
 	}
 
@@ -149,18 +301,22 @@ func (pdns *Client) UpdateRecordSet(ctx context.Context, serverID, zoneID string
 	changeTypeReplace := "REPLACE"
 	rrset.Changetype = &changeTypeReplace
 
-	zone := pdnsclient.Zone{Rrsets: &[]pdnsclient.RRSet{rrset}}
-
-	resp, err := pdns.client.PatchZoneWithResponse(ctx, serverID, zoneID, zone)
-	if err != nil {
-		return err
-	}
-
-	return checkResponse(resp, http.StatusNoContent)
+	return pdns.patchZone(ctx, serverID, zoneID, rrset)
 }
 
 func (pdns *Client) GetRecordSet(ctx context.Context, serverID, zoneID, recordSetName, recordSetType string) (*RecordSet, error) {
-	resp, err := pdns.client.ListZoneWithResponse(ctx, serverID, zoneID, nil)
+	// Ask the server to only return the requested rrset instead of the whole
+	// zone. Servers older than PowerDNS 4.8 ignore these filters and return
+	// all rrsets, which is why the response is still filtered below.
+	// Disabled records are requested explicitly, they are part of the record
+	// set and must not look like drift.
+	includeDisabled := true
+	params := &pdnsclient.ListZoneParams{RrsetName: &recordSetName, IncludeDisabled: &includeDisabled}
+	if recordSetType != "" {
+		params.RrsetType = &recordSetType
+	}
+
+	resp, err := pdns.client.ListZoneWithResponse(ctx, serverID, zoneID, params)
 	if err != nil {
 		return nil, err
 	}
@@ -175,27 +331,25 @@ func (pdns *Client) GetRecordSet(ctx context.Context, serverID, zoneID, recordSe
 
 	rrs := []pdnsclient.RRSet{}
 	for _, rrset := range allRrsets {
-		if rrset.Name == recordSetName {
-			rrs = append(rrs, rrset)
+		if rrset.Name != recordSetName {
+			continue
+		}
+		if recordSetType != "" && rrset.Type != recordSetType {
+			continue
 		}
+		rrs = append(rrs, rrset)
 	}
 
 	switch len(rrs) {
 	case 0:
-		return nil, fmt.Errorf("record set '%s' not found", recordSetName)
+		if recordSetType != "" {
+			return nil, &NotFoundError{msg: fmt.Sprintf("record set '%s' with type '%s' not found", recordSetName, recordSetType)}
+		}
+		return nil, &NotFoundError{msg: fmt.Sprintf("record set '%s' not found", recordSetName)}
 	case 1:
 		return transformAPIToRecordSet(&rrs[0]), nil
 	default:
-		if recordSetType == "" {
-			return nil, errors.New("multiple record sets found with the same name, type required")
-		}
-
-		for _, rrset := range rrs {
-			if rrset.Type == recordSetType {
-				return transformAPIToRecordSet(&rrset), nil
-			}
-		}
-		return nil, fmt.Errorf("record set '%s' with type '%s' not found", recordSetName, recordSetType)
+		return nil, errors.New("multiple record sets found with the same name, type required")
 	}
 }
 
@@ -205,61 +359,100 @@ func (pdns *Client) DeleteRecordSet(ctx context.Context, serverID, zoneID string
 	changeTypeDelete := "DELETE"
 	rrset.Changetype = &changeTypeDelete
 	rrset.Records = []pdnsclient.Record{}
+	rrset.Comments = nil
 	rrset.Ttl = 0
 
-	zone := pdnsclient.Zone{Rrsets: &[]pdnsclient.RRSet{rrset}}
+	return pdns.patchZone(ctx, serverID, zoneID, rrset)
+}
+
+// patchZone applies a single rrset change to a zone. PATCH requests for the
+// same zone are sent one after another, never concurrently. If batching is
+// enabled, the change is combined with other changes for the same zone.
+func (pdns *Client) patchZone(ctx context.Context, serverID, zoneID string, rrset pdnsclient.RRSet) error {
+	if pdns.batcher.window > 0 {
+		return pdns.patchZoneBatched(ctx, serverID, zoneID, rrset)
+	}
 
-	resp, err := pdns.client.PatchZoneWithResponse(ctx, serverID, zoneID, zone)
+	unlock, err := pdns.zoneLocks.lock(ctx, zoneLockKey(serverID, zoneID))
 	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	return checkResponse(resp, http.StatusNoContent)
+	return pdns.sendPatch(ctx, serverID, zoneID, rrset)
 }
 
 func transformRecordSetToAPI(recordSet *RecordSet) pdnsclient.RRSet {
 	records := make([]pdnsclient.Record, len(recordSet.Records))
 	for i, record := range recordSet.Records {
+		disabled := record.Disabled
 		records[i] = pdnsclient.Record{
-			Content: record,
+			Content:  record.Content,
+			Disabled: &disabled,
 		}
 	}
-	return pdnsclient.RRSet{
+	rrset := pdnsclient.RRSet{
 		Name:    recordSet.Name,
 		Type:    recordSet.Type,
 		Ttl:     int(recordSet.TTL),
 		Records: records,
 	}
+	if recordSet.Comments != nil {
+		comments := make([]pdnsclient.Comment, len(recordSet.Comments))
+		for i, comment := range recordSet.Comments {
+			content := comment.Content
+			account := comment.Account
+			comments[i] = pdnsclient.Comment{
+				Content: &content,
+				Account: &account,
+			}
+			if comment.ModifiedAt != 0 {
+				modifiedAt := int(comment.ModifiedAt)
+				comments[i].ModifiedAt = &modifiedAt
+			}
+		}
+		rrset.Comments = &comments
+	}
+	return rrset
 }
 
 func transformAPIToRecordSet(rrset *pdnsclient.RRSet) *RecordSet {
-	records := make([]string, len(rrset.Records))
+	records := make([]Record, len(rrset.Records))
 	for i, record := range rrset.Records {
-		records[i] = record.Content
+		records[i] = Record{Content: record.Content}
+		if record.Disabled != nil {
+			records[i].Disabled = *record.Disabled
+		}
+	}
+	comments := []Comment{}
+	if rrset.Comments != nil {
+		for _, comment := range *rrset.Comments {
+			c := Comment{}
+			if comment.Content != nil {
+				c.Content = *comment.Content
+			}
+			if comment.Account != nil {
+				c.Account = *comment.Account
+			}
+			if comment.ModifiedAt != nil {
+				c.ModifiedAt = int64(*comment.ModifiedAt)
+			}
+			comments = append(comments, c)
+		}
 	}
 	return &RecordSet{
-		Name:    rrset.Name,
-		Type:    rrset.Type,
-		TTL:     int64(rrset.Ttl),
-		Records: records,
+		Name:     rrset.Name,
+		Type:     rrset.Type,
+		TTL:      int64(rrset.Ttl),
+		Records:  records,
+		Comments: comments,
 	}
 }
 
 func transformZoneToAPI(zone *Zone) pdnsclient.Zone {
 	rrsets := make([]pdnsclient.RRSet, len(zone.RecordSets))
-	for i, recordset := range zone.RecordSets {
-		records := make([]pdnsclient.Record, len(recordset.Records))
-		for j, record := range recordset.Records {
-			records[j] = pdnsclient.Record{
-				Content: record,
-			}
-		}
-		rrsets[i] = pdnsclient.RRSet{
-			Name:    recordset.Name,
-			Type:    recordset.Type,
-			Ttl:     int(recordset.TTL),
-			Records: records,
-		}
+	for i := range zone.RecordSets {
+		rrsets[i] = transformRecordSetToAPI(&zone.RecordSets[i])
 	}
 
 	kind := pdnsclient.ZoneKind(zone.Kind)
@@ -268,31 +461,34 @@ func transformZoneToAPI(zone *Zone) pdnsclient.Zone {
 	dnssec := zone.DNSSec
 	masters := zone.Masters
 
-	return pdnsclient.Zone{
-		Name:    &name,
-		Kind:    &kind,
-		Dnssec:  &dnssec,
-		Serial:  &serial,
-		Masters: &masters,
-		Rrsets:  &rrsets,
+	result := pdnsclient.Zone{
+		Name:       &name,
+		Kind:       &kind,
+		Dnssec:     &dnssec,
+		Serial:     &serial,
+		Masters:    &masters,
+		Rrsets:     &rrsets,
+		SoaEdit:    zone.SoaEdit,
+		SoaEditApi: zone.SoaEditAPI,
+		Account:    zone.Account,
+		Catalog:    zone.Catalog,
+		ApiRectify: zone.APIRectify,
+		Presigned:  zone.Presigned,
+	}
+	if len(zone.Nameservers) > 0 {
+		nameservers := zone.Nameservers
+		result.Nameservers = &nameservers
 	}
+
+	return result
 }
 
 func transformAPIToZone(zone *pdnsclient.Zone) *Zone {
 	var recordsets []RecordSet
 	if zone.Rrsets != nil {
 		recordsets = make([]RecordSet, len(*zone.Rrsets))
-		for i, rrset := range *zone.Rrsets {
-			records := make([]string, len(rrset.Records))
-			for j, record := range rrset.Records {
-				records[j] = record.Content
-			}
-			recordsets[i] = RecordSet{
-				Name:    rrset.Name,
-				Type:    rrset.Type,
-				TTL:     int64(rrset.Ttl),
-				Records: records,
-			}
+		for i := range *zone.Rrsets {
+			recordsets[i] = *transformAPIToRecordSet(&(*zone.Rrsets)[i])
 		}
 	}
 
@@ -315,6 +511,15 @@ func transformAPIToZone(zone *pdnsclient.Zone) *Zone {
 	if zone.Masters != nil {
 		result.Masters = *zone.Masters
 	}
+	if zone.Nameservers != nil {
+		result.Nameservers = *zone.Nameservers
+	}
+	result.SoaEdit = zone.SoaEdit
+	result.SoaEditAPI = zone.SoaEditApi
+	result.Account = zone.Account
+	result.Catalog = zone.Catalog
+	result.APIRectify = zone.ApiRectify
+	result.Presigned = zone.Presigned
 
 	return result
 }
@@ -0,0 +1,162 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := New(context.Background(), "secret", serverURL.Host, "/api/v1", serverURL.Scheme, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+const testZoneJSON = `{
+  "id": "example.net.",
+  "name": "example.net.",
+  "kind": "Native",
+  "rrsets": [
+    {"name": "example.net.", "type": "NS", "ttl": 1500, "records": [{"content": "ns1.example.net."}]},
+    {"name": "www.example.net.", "type": "A", "ttl": 3600, "records": [{"content": "192.0.2.1"}]},
+    {"name": "www.example.net.", "type": "AAAA", "ttl": 3600, "records": [{"content": "2001:db8::1"}, {"content": "2001:db8::2", "disabled": true}]}
+  ]
+}`
+
+func TestCheckResponseNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		notFound bool
+	}{
+		{"404 with error", http.StatusNotFound, `{"error": "Not Found"}`, true},
+		{"404 without body", http.StatusNotFound, "", true},
+		{"422 unknown zone", http.StatusUnprocessableEntity, `{"error": "Could not find domain 'example.net.'"}`, true},
+		{"422 other error", http.StatusUnprocessableEntity, `{"error": "Domain 'example.net.' already exists"}`, false},
+		{"422 without body", http.StatusUnprocessableEntity, "", false},
+		{"500", http.StatusInternalServerError, `{"error": "Could not find domain 'example.net.'"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.body != "" {
+					w.Header().Set("Content-Type", "application/json")
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			_, err := client.GetZone(context.Background(), "localhost", "example.net.")
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if IsNotFound(err) != tt.notFound {
+				t.Errorf("expected IsNotFound %t, got %t for %v", tt.notFound, IsNotFound(err), err)
+			}
+		})
+	}
+}
+
+func TestGetRecordSetFilters(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("rrset_name"); got != "www.example.net." {
+			t.Errorf("expected rrset_name filter 'www.example.net.', got '%s'", got)
+		}
+		if got := query.Get("rrset_type"); got != "AAAA" {
+			t.Errorf("expected rrset_type filter 'AAAA', got '%s'", got)
+		}
+		if got := query.Get("include_disabled"); got != "true" {
+			t.Errorf("expected include_disabled 'true', got '%s'", got)
+		}
+		// Answer like a server without filter support, with the whole zone.
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testZoneJSON))
+	}))
+
+	recordSet, err := client.GetRecordSet(context.Background(), "localhost", "example.net.", "www.example.net.", "AAAA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recordSet.Type != "AAAA" || len(recordSet.Records) != 2 || recordSet.Records[0].Content != "2001:db8::1" || !recordSet.Records[1].Disabled {
+		t.Errorf("unexpected record set: %+v", recordSet)
+	}
+}
+
+func TestGetRecordSetNotFound(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/servers/localhost/zones/unknown.net." {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"error": "Could not find domain 'unknown.net.'"}`))
+			return
+		}
+		_, _ = w.Write([]byte(testZoneJSON))
+	}))
+
+	_, err := client.GetRecordSet(context.Background(), "localhost", "example.net.", "mail.example.net.", "A")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error for unknown record set, got %v", err)
+	}
+	_, err = client.GetRecordSet(context.Background(), "localhost", "unknown.net.", "www.unknown.net.", "A")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error for unknown zone, got %v", err)
+	}
+}
+
+func TestGetRecordSetWrongType(t *testing.T) {
+	// Answer like a server without filter support, with the whole zone. The
+	// only rrset named example.net. has type NS.
+	client := newTestClient(t, zoneHandler())
+
+	_, err := client.GetRecordSet(context.Background(), "localhost", "example.net.", "example.net.", "A")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error for record set of other type, got %v", err)
+	}
+}
+
+func TestUpdateRecordSetComments(t *testing.T) {
+	var rrsets []map[string]json.RawMessage
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Rrsets []map[string]json.RawMessage `json:"rrsets"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		rrsets = body.Rrsets
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	recordSet := &RecordSet{Name: "www.example.net.", Type: "A", TTL: 3600, Records: []Record{{Content: "192.0.2.1"}}}
+	if err := client.UpdateRecordSet(context.Background(), "localhost", "example.net.", recordSet); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rrsets[0]["comments"]; ok {
+		t.Errorf("expected comments to be omitted to keep existing ones, got %s", rrsets[0]["comments"])
+	}
+
+	recordSet.Comments = []Comment{}
+	if err := client.UpdateRecordSet(context.Background(), "localhost", "example.net.", recordSet); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(rrsets[0]["comments"]); got != "[]" {
+		t.Errorf("expected empty comments list to clear comments, got %s", got)
+	}
+}
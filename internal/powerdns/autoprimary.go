@@ -0,0 +1,139 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+type Autoprimary struct {
+	IP         string
+	Nameserver string
+	Account    string
+}
+
+// rawResponse adapts a plain HTTP response to apiResponse, for operations
+// whose generated response parser can't be used.
+type rawResponse struct {
+	status int
+	body   []byte
+}
+
+func (r rawResponse) StatusCode() int                           { return r.status }
+func (r rawResponse) GetBody() []byte                           { return r.body }
+func (r rawResponse) GetJSONDefault() *pdnsclient.ErrorResponse { return nil }
+
+// GetAutoprimaries lists all autoprimaries of the server.
+func (pdns *Client) GetAutoprimaries(ctx context.Context, serverID string) ([]Autoprimary, error) {
+	// The OpenAPI spec of PowerDNS declares a single object as response, while
+	// the server answers with a list. The generated parser fails to decode it,
+	// so the response is decoded by hand.
+	raw, ok := pdns.client.(pdnsclient.ClientInterface)
+	if !ok {
+		return nil, errors.New("powerdns client does not support listing autoprimaries")
+	}
+	httpResp, err := raw.GetAutoprimaries(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(rawResponse{status: httpResp.StatusCode, body: body}, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var autoprimaries []pdnsclient.Autoprimary
+	if err := json.Unmarshal(body, &autoprimaries); err != nil {
+		return nil, fmt.Errorf("decoding autoprimaries: %w", err)
+	}
+
+	result := make([]Autoprimary, 0, len(autoprimaries))
+	for i := range autoprimaries {
+		result = append(result, *transformAPIToAutoprimary(&autoprimaries[i]))
+	}
+	return result, nil
+}
+
+// GetAutoprimary returns the autoprimary with the given ip and nameserver. A
+// NotFoundError is returned if the server has no such autoprimary.
+func (pdns *Client) GetAutoprimary(ctx context.Context, serverID, ip, nameserver string) (*Autoprimary, error) {
+	autoprimaries, err := pdns.GetAutoprimaries(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range autoprimaries {
+		if sameIP(autoprimaries[i].IP, ip) && strings.EqualFold(autoprimaries[i].Nameserver, nameserver) {
+			return &autoprimaries[i], nil
+		}
+	}
+	return nil, &NotFoundError{msg: fmt.Sprintf("autoprimary %s/%s not found", ip, nameserver)}
+}
+
+// sameIP reports whether a and b denote the same address. The server may
+// print addresses differently than they were configured, e.g. IPv6 addresses
+// in their compressed form.
+func sameIP(a, b string) bool {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return addrA == addrB
+}
+
+func (pdns *Client) CreateAutoprimary(ctx context.Context, serverID string, autoprimary *Autoprimary) error {
+	resp, err := pdns.client.CreateAutoprimaryWithResponse(ctx, serverID, transformAutoprimaryToAPI(autoprimary))
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(resp, http.StatusCreated)
+}
+
+func (pdns *Client) DeleteAutoprimary(ctx context.Context, serverID, ip, nameserver string) error {
+	resp, err := pdns.client.DeleteAutoprimaryWithResponse(ctx, serverID, ip, nameserver)
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(resp, http.StatusNoContent)
+}
+
+func transformAutoprimaryToAPI(autoprimary *Autoprimary) pdnsclient.Autoprimary {
+	ip := autoprimary.IP
+	nameserver := autoprimary.Nameserver
+	account := autoprimary.Account
+
+	return pdnsclient.Autoprimary{
+		Ip:         &ip,
+		Nameserver: &nameserver,
+		Account:    &account,
+	}
+}
+
+func transformAPIToAutoprimary(autoprimary *pdnsclient.Autoprimary) *Autoprimary {
+	result := &Autoprimary{}
+	if autoprimary.Ip != nil {
+		result.IP = *autoprimary.Ip
+	}
+	if autoprimary.Nameserver != nil {
+		result.Nameserver = *autoprimary.Nameserver
+	}
+	if autoprimary.Account != nil {
+		result.Account = *autoprimary.Account
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetAutoprimaries(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/servers/localhost/autoprimaries" {
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+  {"ip": "192.0.2.1", "nameserver": "ns1.example.net", "account": "ops"},
+  {"ip": "2001:db8::1", "nameserver": "ns2.example.net", "account": ""}
+]`))
+	}))
+
+	autoprimaries, err := client.GetAutoprimaries(context.Background(), "localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(autoprimaries) != 2 {
+		t.Fatalf("expected 2 autoprimaries, got %d", len(autoprimaries))
+	}
+	if autoprimaries[0].Account != "ops" {
+		t.Errorf("expected account 'ops', got %q", autoprimaries[0].Account)
+	}
+
+	autoprimary, err := client.GetAutoprimary(context.Background(), "localhost", "2001:0db8:0:0::1", "NS2.example.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if autoprimary.IP != "2001:db8::1" {
+		t.Errorf("expected ip '2001:db8::1', got %q", autoprimary.IP)
+	}
+
+	_, err = client.GetAutoprimary(context.Background(), "localhost", "192.0.2.2", "ns1.example.net")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error for unknown autoprimary, got %v", err)
+	}
+}
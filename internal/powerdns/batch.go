@@ -0,0 +1,150 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+// rrsetBatcher collects rrset changes for the same zone that arrive within a
+// short window and sends them to the server in a single PATCH request.
+type rrsetBatcher struct {
+	window time.Duration
+
+	// schedule arranges for flush to be called once the window has passed.
+	// It is only replaced by tests, nil uses a timer.
+	schedule func(window time.Duration, flush func())
+
+	mu      sync.Mutex
+	pending map[string]*rrsetBatch
+}
+
+// rrsetBatch is a set of rrset changes for one zone waiting to be sent.
+type rrsetBatch struct {
+	serverID string
+	zoneID   string
+	rrsets   []pdnsclient.RRSet
+
+	// errs holds the result for each rrset once done is closed.
+	errs []error
+	done chan struct{}
+}
+
+// contains reports whether the batch already has a change for the rrset
+// identified by name and type. PowerDNS rejects PATCH requests that contain
+// the same rrset twice.
+func (b *rrsetBatch) contains(rrset pdnsclient.RRSet) bool {
+	for _, r := range b.rrsets {
+		if r.Name == rrset.Name && r.Type == rrset.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// patchZoneBatched queues an rrset change for the zone and waits until the
+// batch it was added to has been sent.
+func (pdns *Client) patchZoneBatched(ctx context.Context, serverID, zoneID string, rrset pdnsclient.RRSet) error {
+	key := zoneLockKey(serverID, zoneID)
+	b := &pdns.batcher
+
+	for {
+		b.mu.Lock()
+		if b.pending == nil {
+			b.pending = make(map[string]*rrsetBatch)
+		}
+		batch, ok := b.pending[key]
+		if ok && batch.contains(rrset) {
+			// Changes to the same rrset must be applied in order, wait for
+			// the current batch and queue the change in the next one.
+			b.mu.Unlock()
+			select {
+			case <-batch.done:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if !ok {
+			batch = &rrsetBatch{serverID: serverID, zoneID: zoneID, done: make(chan struct{})}
+			b.pending[key] = batch
+			b.scheduleFlush(func() { pdns.flushBatch(key, batch) })
+		}
+		index := len(batch.rrsets)
+		batch.rrsets = append(batch.rrsets, rrset)
+		b.mu.Unlock()
+
+		select {
+		case <-batch.done:
+			return batch.errs[index]
+		case <-ctx.Done():
+			// The change has already been queued and may still be applied.
+			return ctx.Err()
+		}
+	}
+}
+
+// scheduleFlush calls flush once the batch window has passed.
+func (b *rrsetBatcher) scheduleFlush(flush func()) {
+	if b.schedule != nil {
+		b.schedule(b.window, flush)
+		return
+	}
+	time.AfterFunc(b.window, flush)
+}
+
+// flushBatch sends all changes of the batch and reports the results back to
+// the waiting callers.
+func (pdns *Client) flushBatch(key string, batch *rrsetBatch) {
+	b := &pdns.batcher
+	defer close(batch.done)
+
+	// The callers may have given up waiting already, so the requests are not
+	// bound to any of their contexts.
+	ctx := context.Background()
+
+	// Take the zone's lock before closing the batch. Changes arriving while
+	// an earlier PATCH is still in flight are added to this batch, and changes
+	// queued after it is closed can't overtake it.
+	unlock, _ := pdns.zoneLocks.lock(ctx, key)
+	defer unlock()
+
+	b.mu.Lock()
+	if b.pending[key] == batch {
+		delete(b.pending, key)
+	}
+	b.mu.Unlock()
+
+	batch.errs = make([]error, len(batch.rrsets))
+
+	err := pdns.sendPatch(ctx, batch.serverID, batch.zoneID, batch.rrsets...)
+	if err == nil || len(batch.rrsets) == 1 {
+		for i := range batch.errs {
+			batch.errs[i] = err
+		}
+		return
+	}
+
+	// PowerDNS applies a PATCH atomically, so a single invalid rrset fails the
+	// whole batch. Retry each change on its own to attribute the error to the
+	// rrset that caused it.
+	for i, rrset := range batch.rrsets {
+		batch.errs[i] = pdns.sendPatch(ctx, batch.serverID, batch.zoneID, rrset)
+	}
+}
+
+// sendPatch sends one PATCH request with the given rrset changes. The caller
+// must hold the zone's lock.
+func (pdns *Client) sendPatch(ctx context.Context, serverID, zoneID string, rrsets ...pdnsclient.RRSet) error {
+	zone := pdnsclient.Zone{Rrsets: &rrsets}
+
+	resp, err := pdns.client.PatchZoneWithResponse(ctx, serverID, zoneID, zone)
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(resp, http.StatusNoContent)
+}
@@ -0,0 +1,163 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+// manualFlush makes the test decide when the batches of client are sent,
+// instead of a timer. The returned function waits until the pending batch of
+// the zone example.net. holds n changes and sends it.
+func manualFlush(t *testing.T, client *Client) func(n int) {
+	t.Helper()
+
+	flushes := make(chan func(), 10)
+	client.batcher.schedule = func(_ time.Duration, flush func()) {
+		flushes <- flush
+	}
+
+	key := zoneLockKey("localhost", "example.net.")
+	return func(n int) {
+		t.Helper()
+
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			client.batcher.mu.Lock()
+			batch := client.batcher.pending[key]
+			queued := batch != nil && len(batch.rrsets) == n
+			client.batcher.mu.Unlock()
+			if queued {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for a batch of %d changes", n)
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		select {
+		case flush := <-flushes:
+			flush()
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for a batch to be scheduled")
+		}
+	}
+}
+
+func TestPatchZoneBatched(t *testing.T) {
+	var mu sync.Mutex
+	var patchSizes []int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var zone pdnsclient.Zone
+		if err := json.NewDecoder(r.Body).Decode(&zone); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		patchSizes = append(patchSizes, len(*zone.Rrsets))
+		mu.Unlock()
+
+		for _, rrset := range *zone.Rrsets {
+			if rrset.Name == "invalid.example.net." {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"error": "invalid rrset"}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}), WithBatchWindow(time.Hour))
+	flush := manualFlush(t, client)
+
+	names := []string{"a.example.net.", "b.example.net.", "c.example.net.", "d.example.net."}
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recordSet := &RecordSet{Name: name, Type: "A", TTL: 300, Records: []Record{{Content: "192.0.2.1"}}}
+			errs[i] = client.UpdateRecordSet(context.Background(), "localhost", "example.net.", recordSet)
+		}()
+	}
+	flush(len(names))
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", names[i], err)
+		}
+	}
+	if fmt.Sprint(patchSizes) != "[4]" {
+		t.Errorf("expected a single PATCH with 4 rrsets, got %v", patchSizes)
+	}
+
+	// An invalid rrset only fails its own change.
+	patchSizes = nil
+	names = []string{"a.example.net.", "invalid.example.net.", "b.example.net."}
+	errs = make([]error, len(names))
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recordSet := &RecordSet{Name: name, Type: "A", TTL: 300, Records: []Record{{Content: "192.0.2.1"}}}
+			errs[i] = client.UpdateRecordSet(context.Background(), "localhost", "example.net.", recordSet)
+		}()
+	}
+	flush(len(names))
+	wg.Wait()
+
+	for i, err := range errs {
+		if (err != nil) != (names[i] == "invalid.example.net.") {
+			t.Errorf("unexpected result for %s: %v", names[i], err)
+		}
+	}
+	if fmt.Sprint(patchSizes) != "[3 1 1 1]" {
+		t.Errorf("expected the failed batch to be retried per rrset, got %v", patchSizes)
+	}
+}
+
+func TestPatchZoneBatchedSameRRSet(t *testing.T) {
+	var mu sync.Mutex
+	var patchSizes []int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var zone pdnsclient.Zone
+		if err := json.NewDecoder(r.Body).Decode(&zone); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		patchSizes = append(patchSizes, len(*zone.Rrsets))
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}), WithBatchWindow(time.Hour))
+	flush := manualFlush(t, client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recordSet := &RecordSet{Name: "www.example.net.", Type: "A", TTL: 300, Records: []Record{{Content: "192.0.2.1"}}}
+			if err := client.UpdateRecordSet(context.Background(), "localhost", "example.net.", recordSet); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	// The second change waits for the first batch and is sent in a batch
+	// of its own.
+	flush(1)
+	flush(1)
+	wg.Wait()
+
+	// PowerDNS rejects PATCH requests changing the same rrset twice.
+	if fmt.Sprint(patchSizes) != "[1 1]" {
+		t.Errorf("expected two separate PATCH requests, got %v", patchSizes)
+	}
+}
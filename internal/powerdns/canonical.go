@@ -0,0 +1,181 @@
+package powerdns
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// CanonicalContent returns content of a record of type rrType in the form
+// PowerDNS stores it. Hostnames are lowercased and made absolute, addresses
+// are printed in their shortest form and character strings are re-quoted.
+// Content of unknown types or content that can't be parsed is only trimmed,
+// the server has the final word on its validity.
+func CanonicalContent(rrType, content string) string {
+	content = strings.TrimSpace(content)
+
+	switch strings.ToUpper(rrType) {
+	case "A", "AAAA":
+		if addr, err := netip.ParseAddr(content); err == nil {
+			return addr.String()
+		}
+	case "CNAME", "NS", "PTR":
+		return canonicalName(content)
+	case "MX":
+		// <preference> <exchange>
+		return canonicalFields(content, canonicalNumber, canonicalName)
+	case "SRV":
+		// <priority> <weight> <port> <target>
+		return canonicalFields(content, canonicalNumber, canonicalNumber, canonicalNumber, canonicalName)
+	case "SOA":
+		// <mname> <rname> <serial> <refresh> <retry> <expire> <minimum>
+		return canonicalFields(content, canonicalName, canonicalName, canonicalNumber, canonicalNumber, canonicalNumber, canonicalNumber, canonicalNumber)
+	case "TXT", "SPF":
+		if strs, err := parseCharacterStrings(content); err == nil {
+			return quoteCharacterStrings(strs)
+		}
+	case "CAA":
+		return canonicalCAA(content)
+	}
+
+	return content
+}
+
+// SameContent reports whether a and b are the same content of a record of
+// type rrType, once both are brought into canonical form.
+func SameContent(rrType, a, b string) bool {
+	return CanonicalContent(rrType, a) == CanonicalContent(rrType, b)
+}
+
+func canonicalName(name string) string {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+func canonicalNumber(number string) string {
+	n, err := strconv.ParseUint(number, 10, 32)
+	if err != nil {
+		return number
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// canonicalFields canonicalizes the whitespace separated fields of content
+// with the given functions. Content with an unexpected number of fields is
+// returned unchanged.
+func canonicalFields(content string, funcs ...func(string) string) string {
+	fields := strings.Fields(content)
+	if len(fields) != len(funcs) {
+		return content
+	}
+	for i, f := range funcs {
+		fields[i] = f(fields[i])
+	}
+	return strings.Join(fields, " ")
+}
+
+// canonicalCAA canonicalizes content of a CAA record, which has the form
+// <flags> <tag> "<value>".
+func canonicalCAA(content string) string {
+	fields := strings.SplitN(content, " ", 3)
+	if len(fields) != 3 {
+		return content
+	}
+	value, err := parseCharacterStrings(fields[2])
+	if err != nil || len(value) != 1 {
+		return content
+	}
+	return fmt.Sprintf("%s %s %s", canonicalNumber(fields[0]), strings.ToLower(fields[1]), quoteCharacterStrings(value))
+}
+
+// parseCharacterStrings splits content into its character strings. Quoted
+// strings may contain whitespace and escape sequences (\X or \DDD), unquoted
+// strings end at the next whitespace.
+func parseCharacterStrings(content string) ([]string, error) {
+	var strs []string
+
+	for i := 0; i < len(content); {
+		switch content[i] {
+		case ' ', '\t':
+			i++
+			continue
+		}
+
+		quoted := content[i] == '"'
+		if quoted {
+			i++
+		}
+
+		var b strings.Builder
+		closed := false
+		for i < len(content) {
+			c := content[i]
+			if quoted && c == '"' {
+				i++
+				closed = true
+				break
+			}
+			if !quoted && (c == ' ' || c == '\t') {
+				break
+			}
+			if c == '\\' {
+				if i+3 < len(content) && isDigit(content[i+1]) && isDigit(content[i+2]) && isDigit(content[i+3]) {
+					n, _ := strconv.Atoi(content[i+1 : i+4])
+					if n > 255 {
+						return nil, fmt.Errorf("invalid escape sequence %q", content[i:i+4])
+					}
+					b.WriteByte(byte(n))
+					i += 4
+					continue
+				}
+				if i+1 >= len(content) {
+					return nil, fmt.Errorf("unterminated escape sequence")
+				}
+				b.WriteByte(content[i+1])
+				i += 2
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		}
+		if quoted && !closed {
+			return nil, fmt.Errorf("unterminated quoted string")
+		}
+		strs = append(strs, b.String())
+	}
+
+	return strs, nil
+}
+
+// quoteCharacterStrings quotes and joins strs the way PowerDNS prints them.
+// Quotes and backslashes are escaped, non-printable bytes use \DDD escapes.
+func quoteCharacterStrings(strs []string) string {
+	quoted := make([]string, len(strs))
+	for i, s := range strs {
+		var b strings.Builder
+		b.WriteByte('"')
+		for j := 0; j < len(s); j++ {
+			c := s[j]
+			switch {
+			case c == '"' || c == '\\':
+				b.WriteByte('\\')
+				b.WriteByte(c)
+			case c < ' ' || c > '~':
+				fmt.Fprintf(&b, "\\%03d", c)
+			default:
+				b.WriteByte(c)
+			}
+		}
+		b.WriteByte('"')
+		quoted[i] = b.String()
+	}
+	return strings.Join(quoted, " ")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,50 @@
+package powerdns
+
+import "testing"
+
+func TestCanonicalContent(t *testing.T) {
+	tests := []struct {
+		rrType  string
+		content string
+		want    string
+	}{
+		{"A", "192.0.2.1", "192.0.2.1"},
+		{"A", " 192.0.2.1 ", "192.0.2.1"},
+		{"AAAA", "2001:DB8::0001", "2001:db8::1"},
+		{"AAAA", "2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1"},
+		{"CNAME", "WWW.Example.NET", "www.example.net."},
+		{"NS", "ns1.example.net.", "ns1.example.net."},
+		{"PTR", "Host.example.net", "host.example.net."},
+		{"MX", "010   Mail.Example.net", "10 mail.example.net."},
+		{"SRV", "0 5 05060 SIP.example.net", "0 5 5060 sip.example.net."},
+		{"SOA", "NS1.example.net Hostmaster.example.net 2024010101 10800 3600 604800 3600", "ns1.example.net. hostmaster.example.net. 2024010101 10800 3600 604800 3600"},
+		{"TXT", `"v=spf1 -all"`, `"v=spf1 -all"`},
+		{"TXT", `"foo"   "bar"`, `"foo" "bar"`},
+		{"TXT", `"say \"hi\""`, `"say \"hi\""`},
+		{"TXT", `"caf\195\169"`, `"caf\195\169"`},
+		{"TXT", `"\h\i"`, `"hi"`},
+		{"TXT", `"unterminated`, `"unterminated`},
+		{"CAA", `0 ISSUE "letsencrypt.org"`, `0 issue "letsencrypt.org"`},
+		{"CAA", `128 iodef "mailto:ca@example.net"`, `128 iodef "mailto:ca@example.net"`},
+		{"LOC", "52 22 23.000 N 4 53 32.000 E -2.00m", "52 22 23.000 N 4 53 32.000 E -2.00m"},
+		{"a", "192.0.2.1", "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		if got := CanonicalContent(tt.rrType, tt.content); got != tt.want {
+			t.Errorf("CanonicalContent(%q, %q) = %q, want %q", tt.rrType, tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestSameContent(t *testing.T) {
+	if !SameContent("AAAA", "2001:DB8::0001", "2001:db8::1") {
+		t.Error("expected IPv6 addresses to be the same")
+	}
+	if !SameContent("CNAME", "www.example.net", "WWW.EXAMPLE.NET.") {
+		t.Error("expected hostnames to be the same")
+	}
+	if SameContent("TXT", `"Hello"`, `"hello"`) {
+		t.Error("expected TXT content to be case sensitive")
+	}
+}
@@ -0,0 +1,135 @@
+package powerdns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+type Cryptokey struct {
+	ID        int64
+	KeyType   string
+	Active    bool
+	Published bool
+	Algorithm string
+	Bits      int64
+	DNSKey    string
+	DS        []string
+	CDS       []string
+}
+
+func (pdns *Client) CreateCryptokey(ctx context.Context, serverID, zoneID string, key *Cryptokey) (*Cryptokey, error) {
+	if key.KeyType == "" {
+		return nil, errors.New("cryptokey keytype is required")
+	}
+
+	resp, err := pdns.client.CreateCryptokeyWithResponse(ctx, serverID, zoneID, transformCryptokeyToAPI(key))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return transformAPIToCryptokey(resp.JSON201), nil
+}
+
+func (pdns *Client) GetCryptokey(ctx context.Context, serverID, zoneID string, keyID int64) (*Cryptokey, error) {
+	resp, err := pdns.client.GetCryptokeyWithResponse(ctx, serverID, zoneID, strconv.FormatInt(keyID, 10))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return transformAPIToCryptokey(resp.JSON200), nil
+}
+
+// UpdateCryptokey (de)activates and (un)publishes a key. All other properties
+// of a key can't be changed after it has been created.
+func (pdns *Client) UpdateCryptokey(ctx context.Context, serverID, zoneID string, key *Cryptokey) error {
+	// The OpenAPI spec of PowerDNS lacks the request body of this operation,
+	// so it has to be attached to the generated request by hand.
+	withBody, err := withJSONBody(pdnsclient.Cryptokey{
+		Active:    &key.Active,
+		Published: &key.Published,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := pdns.client.ModifyCryptokeyWithResponse(ctx, serverID, zoneID, strconv.FormatInt(key.ID, 10), withBody)
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(resp, http.StatusNoContent)
+}
+
+func (pdns *Client) DeleteCryptokey(ctx context.Context, serverID, zoneID string, keyID int64) error {
+	resp, err := pdns.client.DeleteCryptokeyWithResponse(ctx, serverID, zoneID, strconv.FormatInt(keyID, 10))
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(resp, http.StatusNoContent)
+}
+
+func transformCryptokeyToAPI(key *Cryptokey) pdnsclient.Cryptokey {
+	keyType := pdnsclient.CryptokeyKeytype(key.KeyType)
+	active := key.Active
+	published := key.Published
+
+	result := pdnsclient.Cryptokey{
+		Keytype:   &keyType,
+		Active:    &active,
+		Published: &published,
+	}
+	if key.Algorithm != "" {
+		algorithm := key.Algorithm
+		result.Algorithm = &algorithm
+	}
+	if key.Bits != 0 {
+		bits := int(key.Bits)
+		result.Bits = &bits
+	}
+
+	return result
+}
+
+func transformAPIToCryptokey(key *pdnsclient.Cryptokey) *Cryptokey {
+	result := &Cryptokey{}
+	if key.Id != nil {
+		result.ID = int64(*key.Id)
+	}
+	if key.Keytype != nil {
+		result.KeyType = string(*key.Keytype)
+	}
+	if key.Active != nil {
+		result.Active = *key.Active
+	}
+	if key.Published != nil {
+		result.Published = *key.Published
+	}
+	if key.Algorithm != nil {
+		result.Algorithm = *key.Algorithm
+	}
+	if key.Bits != nil {
+		result.Bits = int64(*key.Bits)
+	}
+	if key.Dnskey != nil {
+		result.DNSKey = *key.Dnskey
+	}
+	if key.Ds != nil {
+		result.DS = *key.Ds
+	}
+	if key.Cds != nil {
+		result.CDS = *key.Cds
+	}
+
+	return result
+}
@@ -0,0 +1,141 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+type Metadata struct {
+	Kind   string
+	Values []string
+}
+
+// builtinMetadataKinds are the metadata kinds known to PowerDNS which can be
+// modified via the API. Other kinds have to start with "X-".
+var builtinMetadataKinds = []string{
+	"ALLOW-AXFR-FROM",
+	"ALLOW-DNSUPDATE-FROM",
+	"ALSO-NOTIFY",
+	"AXFR-SOURCE",
+	"ENABLE-LUA-RECORDS",
+	"FORWARD-DNSUPDATE",
+	"GSS-ACCEPTOR-PRINCIPAL",
+	"GSS-ALLOW-AXFR-PRINCIPAL",
+	"IXFR",
+	"NOTIFY-DNSUPDATE",
+	"PUBLISH-CDNSKEY",
+	"PUBLISH-CDS",
+	"SIGNALING-ZONE",
+	"SLAVE-RENOTIFY",
+	"SOA-EDIT",
+	"SOA-EDIT-DNSUPDATE",
+	"TSIG-ALLOW-DNSUPDATE",
+}
+
+// readOnlyMetadataKinds can be read, but not modified via the API. They are
+// managed through dedicated zone attributes or API endpoints instead.
+var readOnlyMetadataKinds = []string{
+	"API-RECTIFY",
+	"AXFR-MASTER-TSIG",
+	"LUA-AXFR-SCRIPT",
+	"NSEC3NARROW",
+	"NSEC3PARAM",
+	"PRESIGNED",
+	"SOA-EDIT-API",
+	"TSIG-ALLOW-AXFR",
+}
+
+// IsReadOnlyMetadataKind reports whether the API rejects modifications of
+// the given metadata kind.
+func IsReadOnlyMetadataKind(kind string) bool {
+	for _, k := range readOnlyMetadataKinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidMetadataKind reports whether the API accepts modifications of the
+// given metadata kind, which is either a built-in kind or a custom kind
+// starting with "X-".
+func IsValidMetadataKind(kind string) bool {
+	if len(kind) > 2 && strings.EqualFold(kind[:2], "X-") {
+		return true
+	}
+	for _, k := range builtinMetadataKinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pdns *Client) GetMetadata(ctx context.Context, serverID, zoneID, kind string) (*Metadata, error) {
+	resp, err := pdns.client.GetMetadataWithResponse(ctx, serverID, zoneID, kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return transformAPIToMetadata(resp.JSON200), nil
+}
+
+// SetMetadata replaces all values of the given metadata kind.
+func (pdns *Client) SetMetadata(ctx context.Context, serverID, zoneID string, metadata *Metadata) (*Metadata, error) {
+	// The OpenAPI spec of PowerDNS lacks the request body of this operation,
+	// so it has to be attached to the generated request by hand.
+	withBody, err := withJSONBody(transformMetadataToAPI(metadata))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := pdns.client.ModifyMetadataWithResponse(ctx, serverID, zoneID, metadata.Kind, withBody)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return transformAPIToMetadata(resp.JSON200), nil
+}
+
+func (pdns *Client) DeleteMetadata(ctx context.Context, serverID, zoneID, kind string) error {
+	resp, err := pdns.client.DeleteMetadataWithResponse(ctx, serverID, zoneID, kind)
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(resp, http.StatusNoContent)
+}
+
+func transformMetadataToAPI(metadata *Metadata) pdnsclient.Metadata {
+	kind := metadata.Kind
+	values := metadata.Values
+	if values == nil {
+		values = []string{}
+	}
+
+	return pdnsclient.Metadata{
+		Kind:     &kind,
+		Metadata: &values,
+	}
+}
+
+func transformAPIToMetadata(metadata *pdnsclient.Metadata) *Metadata {
+	result := &Metadata{}
+	if metadata.Kind != nil {
+		result.Kind = *metadata.Kind
+	}
+	if metadata.Metadata != nil {
+		result.Values = *metadata.Metadata
+	}
+
+	return result
+}
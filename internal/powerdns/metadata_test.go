@@ -0,0 +1,32 @@
+package powerdns
+
+import "testing"
+
+func TestMetadataKinds(t *testing.T) {
+	tests := []struct {
+		kind     string
+		readOnly bool
+		valid    bool
+	}{
+		{"ALLOW-AXFR-FROM", false, true},
+		{"also-notify", false, true},
+		{"X-CUSTOM", false, true},
+		{"x-custom", false, true},
+		{"PRESIGNED", true, false},
+		{"SOA-EDIT-API", true, false},
+		{"soa-edit-api", true, false},
+		{"SOA-EDIT", false, true},
+		{"CUSTOM", false, false},
+		{"X-", false, false},
+		{"", false, false},
+	}
+
+	for _, test := range tests {
+		if got := IsReadOnlyMetadataKind(test.kind); got != test.readOnly {
+			t.Errorf("IsReadOnlyMetadataKind(%q) = %t, expected %t", test.kind, got, test.readOnly)
+		}
+		if got := IsValidMetadataKind(test.kind); got != test.valid {
+			t.Errorf("IsValidMetadataKind(%q) = %t, expected %t", test.kind, got, test.valid)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package powerdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+// WithMaxConcurrentRequests limits the number of requests that are in flight
+// at the same time to n. A request counts as in flight until its response body
+// is closed. A limit of zero disables the limit.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Client) {
+		c.limits.maxConcurrent = n
+	}
+}
+
+// WithRequestsPerSecond limits the rate at which requests are sent to the
+// server. Requests exceeding the rate are delayed. A rate of zero disables the
+// limit.
+func WithRequestsPerSecond(rate float64) Option {
+	return func(c *Client) {
+		c.limits.rate = rate
+	}
+}
+
+type limitOptions struct {
+	maxConcurrent int
+	rate          float64
+}
+
+func (o limitOptions) enabled() bool {
+	return o.maxConcurrent > 0 || o.rate > 0
+}
+
+// limitDoer sends requests with doer, enforcing a maximum number of
+// concurrent requests and a maximum request rate.
+type limitDoer struct {
+	doer pdnsclient.HttpRequestDoer
+
+	// slots holds a token for every request in flight. It is nil if the
+	// number of concurrent requests is not limited.
+	slots chan struct{}
+
+	// interval is the minimum time between the start of two requests. It is
+	// zero if the rate is not limited.
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+}
+
+func newLimitDoer(doer pdnsclient.HttpRequestDoer, opts limitOptions) *limitDoer {
+	d := &limitDoer{doer: doer}
+	if opts.maxConcurrent > 0 {
+		d.slots = make(chan struct{}, opts.maxConcurrent)
+	}
+	if opts.rate > 0 {
+		d.interval = time.Duration(float64(time.Second) / opts.rate)
+	}
+	return d
+}
+
+func (d *limitDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if d.slots != nil {
+		select {
+		case d.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if d.slots != nil {
+			<-d.slots
+		}
+	}
+
+	if err := d.waitForRate(ctx); err != nil {
+		release()
+		return nil, err
+	}
+
+	resp, err := d.doer.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// waitForRate blocks until the next request may be sent without exceeding
+// the rate limit.
+func (d *limitDoer) waitForRate(ctx context.Context) error {
+	if d.interval == 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	now := time.Now()
+	if d.next.Before(now) {
+		d.next = now
+	}
+	wait := d.next.Sub(now)
+	d.next = d.next.Add(d.interval)
+	d.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releaseBody calls release once the response body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
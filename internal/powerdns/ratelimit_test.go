@@ -0,0 +1,71 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		zoneHandler().ServeHTTP(w, r)
+	}), WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	client := newTestClient(t, zoneHandler(), WithRequestsPerSecond(100))
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first request is sent immediately, the others 10ms apart.
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected 6 requests to take at least 50ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimitContextCanceled(t *testing.T) {
+	client := newTestClient(t, zoneHandler(), WithRequestsPerSecond(0.1))
+
+	if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetZone(ctx, "localhost", "example.net."); err == nil {
+		t.Error("expected error when the context is canceled while waiting, got none")
+	}
+}
@@ -0,0 +1,140 @@
+package powerdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrRecordExists is returned by AddRecord if the record set already has a
+// record with the same content.
+var ErrRecordExists = errors.New("record already exists")
+
+// GetRecord returns the record set of the given name and type with only the
+// record whose content is semantically equal to content.
+func (pdns *Client) GetRecord(ctx context.Context, serverID, zoneID, name, rrType, content string) (*RecordSet, error) {
+	recordSet, err := pdns.GetRecordSet(ctx, serverID, zoneID, name, rrType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range recordSet.Records {
+		if SameContent(rrType, record.Content, content) {
+			recordSet.Records = []Record{record}
+			return recordSet, nil
+		}
+	}
+
+	return nil, &NotFoundError{msg: fmt.Sprintf("record '%s' not found in record set '%s' (type '%s')", content, name, rrType)}
+}
+
+// AddRecord adds record to the record set of the given name and type. The
+// record set is created with ttl if it doesn't exist yet. Other records of the
+// record set are left untouched.
+//
+// AddRecord fails with ErrRecordExists if the record set already has a record
+// with the same content, unless overwrite is true. As the TTL applies to the
+// whole record set, it also fails if ttl differs from the TTL of the other
+// records of the record set.
+func (pdns *Client) AddRecord(ctx context.Context, serverID, zoneID, name, rrType string, ttl int64, record Record, overwrite bool) error {
+	unlock, err := pdns.rrsetLocks.lock(ctx, rrsetLockKey(serverID, zoneID, name, rrType))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	recordSet, err := pdns.GetRecordSet(ctx, serverID, zoneID, name, rrType)
+	if IsNotFound(err) {
+		recordSet = &RecordSet{Name: name, Type: rrType}
+	} else if err != nil {
+		return err
+	}
+
+	others := otherRecords(rrType, recordSet.Records, record.Content)
+	if len(others) < len(recordSet.Records) && !overwrite {
+		return fmt.Errorf("%w: record '%s' in record set '%s' (type '%s')", ErrRecordExists, record.Content, name, rrType)
+	}
+	if len(others) > 0 && recordSet.TTL != ttl {
+		return fmt.Errorf("record set '%s' (type '%s') has TTL %d, which differs from %d. "+
+			"The TTL applies to the whole record set, all its records must use the same TTL", name, rrType, recordSet.TTL, ttl)
+	}
+
+	return pdns.putRecord(ctx, serverID, zoneID, recordSet, ttl, record, others)
+}
+
+// PutRecord adds record to the record set of the given name and type, or
+// updates it if the record set already has it. The record set is created if
+// it doesn't exist yet, and its TTL is set to ttl, which changes the TTL of
+// all its records. Other records of the record set are left untouched.
+func (pdns *Client) PutRecord(ctx context.Context, serverID, zoneID, name, rrType string, ttl int64, record Record) error {
+	unlock, err := pdns.rrsetLocks.lock(ctx, rrsetLockKey(serverID, zoneID, name, rrType))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	recordSet, err := pdns.GetRecordSet(ctx, serverID, zoneID, name, rrType)
+	if IsNotFound(err) {
+		recordSet = &RecordSet{Name: name, Type: rrType}
+	} else if err != nil {
+		return err
+	}
+
+	return pdns.putRecord(ctx, serverID, zoneID, recordSet, ttl, record, otherRecords(rrType, recordSet.Records, record.Content))
+}
+
+// putRecord replaces recordSet with record and others. The caller must hold
+// the rrset's lock.
+func (pdns *Client) putRecord(ctx context.Context, serverID, zoneID string, recordSet *RecordSet, ttl int64, record Record, others []Record) error {
+	recordSet.TTL = ttl
+	recordSet.Records = append([]Record{record}, others...)
+	// Leave the comments out, so the server keeps them.
+	recordSet.Comments = nil
+
+	return pdns.UpdateRecordSet(ctx, serverID, zoneID, recordSet)
+}
+
+// otherRecords returns the records whose content is not semantically equal
+// to content.
+func otherRecords(rrType string, records []Record, content string) []Record {
+	var others []Record
+	for _, r := range records {
+		if !SameContent(rrType, r.Content, content) {
+			others = append(others, r)
+		}
+	}
+	return others
+}
+
+// RemoveRecord removes the record with the given content from the record set
+// of the given name and type. The record set is deleted once its last record
+// is removed. Removing a record that doesn't exist is not an error.
+func (pdns *Client) RemoveRecord(ctx context.Context, serverID, zoneID, name, rrType, content string) error {
+	unlock, err := pdns.rrsetLocks.lock(ctx, rrsetLockKey(serverID, zoneID, name, rrType))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	recordSet, err := pdns.GetRecordSet(ctx, serverID, zoneID, name, rrType)
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	records := otherRecords(rrType, recordSet.Records, content)
+	if len(records) == len(recordSet.Records) {
+		return nil
+	}
+
+	if len(records) == 0 {
+		return pdns.DeleteRecordSet(ctx, serverID, zoneID, recordSet)
+	}
+
+	recordSet.Records = records
+	recordSet.Comments = nil
+
+	return pdns.UpdateRecordSet(ctx, serverID, zoneID, recordSet)
+}
@@ -0,0 +1,161 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+// fakeRecordSetServer keeps the rrsets of a single zone in memory and
+// answers GET and PATCH requests for it.
+type fakeRecordSetServer struct {
+	mu     sync.Mutex
+	rrsets map[string]pdnsclient.RRSet
+	// deletes counts the rrsets deleted with changetype DELETE.
+	deletes int
+}
+
+func (s *fakeRecordSetServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		rrsets := []pdnsclient.RRSet{}
+		for _, rrset := range s.rrsets {
+			if rrset.Name == r.URL.Query().Get("rrset_name") {
+				rrsets = append(rrsets, rrset)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pdnsclient.Zone{Rrsets: &rrsets})
+	case http.MethodPatch:
+		var zone pdnsclient.Zone
+		if err := json.NewDecoder(r.Body).Decode(&zone); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, rrset := range *zone.Rrsets {
+			key := rrset.Name + "/" + rrset.Type
+			if *rrset.Changetype == "DELETE" {
+				delete(s.rrsets, key)
+				s.deletes++
+				continue
+			}
+			s.rrsets[key] = rrset
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestPutAndRemoveRecord(t *testing.T) {
+	server := &fakeRecordSetServer{rrsets: map[string]pdnsclient.RRSet{}}
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	// Records added concurrently must not overwrite each other.
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record := Record{Content: fmt.Sprintf("192.0.2.%d", i)}
+			if err := client.PutRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", 300, record); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	recordSet, err := client.GetRecordSet(ctx, "localhost", "example.net.", "api.example.net.", "A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordSet.Records) != 10 {
+		t.Fatalf("expected 10 records, got %+v", recordSet.Records)
+	}
+
+	// Updating a record replaces it instead of adding another one.
+	if err := client.PutRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", 300, Record{Content: "192.0.2.1", Disabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	record, err := client.GetRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record.Records) != 1 || !record.Records[0].Disabled {
+		t.Errorf("expected the updated record to be disabled, got %+v", record.Records)
+	}
+
+	for i := 1; i <= 9; i++ {
+		if err := client.RemoveRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", fmt.Sprintf("192.0.2.%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if server.deletes != 0 {
+		t.Errorf("expected the record set to be kept while it has records, got %d deletes", server.deletes)
+	}
+	if _, err := client.GetRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", "192.0.2.1"); !IsNotFound(err) {
+		t.Errorf("expected not found error for removed record, got %v", err)
+	}
+
+	// Removing the last record deletes the record set.
+	if err := client.RemoveRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", "192.0.2.10"); err != nil {
+		t.Fatal(err)
+	}
+	if server.deletes != 1 || len(server.rrsets) != 0 {
+		t.Errorf("expected the record set to be deleted, got %d deletes and rrsets %+v", server.deletes, server.rrsets)
+	}
+	if err := client.RemoveRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", "192.0.2.10"); err != nil {
+		t.Errorf("expected removing a missing record to succeed, got %v", err)
+	}
+}
+
+func TestAddRecord(t *testing.T) {
+	server := &fakeRecordSetServer{rrsets: map[string]pdnsclient.RRSet{}}
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	if err := client.AddRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", 300, Record{Content: "192.0.2.1"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// A record that already exists is not taken over unnoticed.
+	err := client.AddRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", 300, Record{Content: "192.0.2.1"}, false)
+	if !errors.Is(err, ErrRecordExists) {
+		t.Errorf("expected ErrRecordExists, got %v", err)
+	}
+	if err := client.AddRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", 300, Record{Content: "192.0.2.1", Disabled: true}, true); err != nil {
+		t.Errorf("expected overwriting an existing record to succeed, got %v", err)
+	}
+
+	// All records of a record set share its TTL.
+	err = client.AddRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", 600, Record{Content: "192.0.2.2"}, false)
+	if err == nil || !strings.Contains(err.Error(), "TTL") {
+		t.Errorf("expected error for differing TTL, got %v", err)
+	}
+	if err := client.AddRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", 300, Record{Content: "192.0.2.2"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Updating the TTL of one record changes it for the whole record set.
+	if err := client.PutRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", 600, Record{Content: "192.0.2.2"}); err != nil {
+		t.Fatal(err)
+	}
+	record, err := client.GetRecord(ctx, "localhost", "example.net.", "api.example.net.", "A", "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.TTL != 600 || !record.Records[0].Disabled {
+		t.Errorf("expected disabled record with TTL 600, got TTL %d and %+v", record.TTL, record.Records)
+	}
+}
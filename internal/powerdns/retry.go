@@ -0,0 +1,235 @@
+package powerdns
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+const (
+	// DefaultRetryMaxWait is the longest wait between two attempts of a
+	// request if WithRetry is used without a maximum.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	// retryBaseWait is the wait before the first retry. It doubles with
+	// every further attempt.
+	retryBaseWait = 500 * time.Millisecond
+)
+
+// WithRetry enables retries of requests that failed with a transient error,
+// like a 5xx or 429 response, a reset connection or a timeout. A request is
+// sent at most maxRetries+1 times, with an exponentially growing wait of at
+// most maxWait between the attempts. A maxWait of zero uses
+// DefaultRetryMaxWait.
+func WithRetry(maxRetries int, maxWait time.Duration) Option {
+	return func(c *Client) {
+		if maxWait <= 0 {
+			maxWait = DefaultRetryMaxWait
+		}
+		c.retry = retryOptions{maxRetries: maxRetries, maxWait: maxWait}
+	}
+}
+
+// WithTooManyRequestsRetry retries requests that were rejected with 429 Too
+// Many Requests up to maxRetries times, but no other failed requests. It
+// replaces the settings of WithRetry. A maxWait of zero uses
+// DefaultRetryMaxWait.
+func WithTooManyRequestsRetry(maxRetries int, maxWait time.Duration) Option {
+	return func(c *Client) {
+		if maxWait <= 0 {
+			maxWait = DefaultRetryMaxWait
+		}
+		c.retry = retryOptions{maxRetries: maxRetries, maxWait: maxWait, tooManyRequestsOnly: true}
+	}
+}
+
+type retryOptions struct {
+	maxRetries int
+	maxWait    time.Duration
+
+	// tooManyRequestsOnly restricts retries to 429 Too Many Requests
+	// responses.
+	tooManyRequestsOnly bool
+}
+
+// retryDoer sends requests with doer and retries them on transient errors.
+//
+// Requests are only retried if sending them twice can't do any harm. GET,
+// PUT and DELETE are idempotent. PATCH requests of zones are retried if all
+// their rrset changes are REPLACE or DELETE, which leave the rrset in the
+// same state no matter how often they are applied. Other requests, like POST,
+// are only retried if the connection to the server could not be established
+// or the server answered with 429 Too Many Requests, so they were never
+// processed.
+type retryDoer struct {
+	doer pdnsclient.HttpRequestDoer
+	retryOptions
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	idempotent := isIdempotent(req)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := d.doer.Do(attemptReq)
+		retry := shouldRetry(req, idempotent, resp, err)
+		if d.tooManyRequestsOnly {
+			retry = retry && err == nil && resp.StatusCode == http.StatusTooManyRequests
+		}
+		if attempt >= d.maxRetries || !retry {
+			return resp, err
+		}
+
+		wait := d.wait(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// wait returns how long to wait before the next attempt. The wait grows
+// exponentially with full jitter, but is at least as long as requested by a
+// Retry-After header. It never exceeds maxWait.
+func (d *retryDoer) wait(attempt int, resp *http.Response) time.Duration {
+	wait := d.maxWait
+	if attempt < 30 && retryBaseWait<<attempt < d.maxWait {
+		wait = retryBaseWait << attempt
+	}
+	wait = wait/2 + rand.N(wait/2+1)
+
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok && retryAfter > wait {
+			wait = retryAfter
+		}
+	}
+
+	return min(wait, d.maxWait)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// shouldRetry reports whether the outcome of an attempt of req was a
+// transient failure that is safe to retry.
+func shouldRetry(req *http.Request, idempotent bool, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body was consumed by the first attempt and can't be sent again.
+		return false
+	}
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		if isDialError(err) {
+			return true
+		}
+		return idempotent && isTransientError(err)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return idempotent && resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+// isDialError reports whether err occurred while connecting to the server,
+// before any part of the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isTransientError reports whether err is a timeout or a connection that was
+// closed unexpectedly.
+func isTransientError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isIdempotent reports whether sending req more than once has the same
+// effect as sending it once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPatch:
+		return isIdempotentPatch(req)
+	default:
+		return false
+	}
+}
+
+// isIdempotentPatch reports whether req is a PATCH of a zone that only
+// contains REPLACE and DELETE changes. If such a PATCH was applied but its
+// response got lost, applying it again doesn't change the zone any further.
+func isIdempotentPatch(req *http.Request) bool {
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+
+	var zone struct {
+		Rrsets []struct {
+			Changetype string `json:"changetype"`
+		} `json:"rrsets"`
+	}
+	if err := json.NewDecoder(body).Decode(&zone); err != nil || len(zone.Rrsets) == 0 {
+		return false
+	}
+	for _, rrset := range zone.Rrsets {
+		if rrset.Changetype != "REPLACE" && rrset.Changetype != "DELETE" {
+			return false
+		}
+	}
+	return true
+}
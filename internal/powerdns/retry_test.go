@@ -0,0 +1,214 @@
+package powerdns
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyHandler answers the first failures requests with status and passes
+// all later ones to next.
+func flakyHandler(failures int32, status int, next http.Handler) (http.Handler, *int32) {
+	var requests int32
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error": "backend unavailable"}`))
+			return
+		}
+		next.ServeHTTP(w, r)
+	}), &requests
+}
+
+func TestRetryServerError(t *testing.T) {
+	handler, requests := flakyHandler(2, http.StatusServiceUnavailable, zoneHandler())
+	client := newTestClient(t, handler, WithRetry(3, 10*time.Millisecond))
+
+	if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 3 {
+		t.Errorf("expected 3 requests, got %d", *requests)
+	}
+}
+
+func TestRetryMaxRetries(t *testing.T) {
+	handler, requests := flakyHandler(10, http.StatusBadGateway, zoneHandler())
+	client := newTestClient(t, handler, WithRetry(2, 10*time.Millisecond))
+
+	_, err := client.GetZone(context.Background(), "localhost", "example.net.")
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Errorf("expected error with status 502, got %v", err)
+	}
+	if *requests != 3 {
+		t.Errorf("expected 3 requests, got %d", *requests)
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	handler, requests := flakyHandler(1, http.StatusServiceUnavailable, zoneHandler())
+	client := newTestClient(t, handler)
+
+	if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err == nil {
+		t.Error("expected error, got none")
+	}
+	if *requests != 1 {
+		t.Errorf("expected 1 request, got %d", *requests)
+	}
+}
+
+func TestRetryPatch(t *testing.T) {
+	var bodies []string
+	handler, requests := flakyHandler(1, http.StatusInternalServerError, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		handler.ServeHTTP(w, r)
+	}), WithRetry(3, 10*time.Millisecond))
+
+	recordSet := &RecordSet{Name: "www.example.net.", Type: "A", TTL: 300, Records: []Record{{Content: "192.0.2.1"}}}
+	if err := client.UpdateRecordSet(context.Background(), "localhost", "example.net.", recordSet); err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", *requests)
+	}
+	if bodies[0] == "" || bodies[1] != bodies[0] {
+		t.Errorf("expected the retry to send the same body, got %q and %q", bodies[0], bodies[1])
+	}
+}
+
+func TestRetryNotIdempotent(t *testing.T) {
+	handler, requests := flakyHandler(1, http.StatusServiceUnavailable, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	client := newTestClient(t, handler, WithRetry(3, 10*time.Millisecond))
+
+	// Zones are created with POST, which must not be sent twice.
+	_, err := client.CreateZone(context.Background(), "localhost", &Zone{Name: "example.net.", Kind: "Native"})
+	if err == nil {
+		t.Error("expected error, got none")
+	}
+	if *requests != 1 {
+		t.Errorf("expected 1 request, got %d", *requests)
+	}
+
+	// PATCHes with other changes than REPLACE and DELETE aren't retried either.
+	req, err := http.NewRequest(http.MethodPatch, "http://localhost/", strings.NewReader(`{"rrsets": [{"changetype": "EXTEND"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isIdempotent(req) {
+		t.Error("expected PATCH with EXTEND change not to be idempotent")
+	}
+}
+
+func TestRetryConnectionReset(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn.Close()
+			return
+		}
+		zoneHandler().ServeHTTP(w, r)
+	}), WithRetry(3, 10*time.Millisecond))
+
+	if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	handler, _ := flakyHandler(10, http.StatusServiceUnavailable, zoneHandler())
+	client := newTestClient(t, handler, WithRetry(3, time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GetZone(ctx, "localhost", "example.net."); err == nil {
+		t.Error("expected error, got none")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected retries to stop when the context is canceled, took %s", elapsed)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	d := &retryDoer{retryOptions: retryOptions{maxRetries: 10, maxWait: 10 * time.Second}}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		want := min(retryBaseWait<<attempt, d.maxWait)
+		if wait := d.wait(attempt, nil); wait < want/2 || wait > want {
+			t.Errorf("attempt %d: expected wait between %s and %s, got %s", attempt, want/2, want, wait)
+		}
+	}
+	if wait := d.wait(100, nil); wait > d.maxWait {
+		t.Errorf("expected wait of at most %s, got %s", d.maxWait, wait)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if wait := d.wait(0, resp); wait != 5*time.Second {
+		t.Errorf("expected wait of 5s from Retry-After, got %s", wait)
+	}
+	resp.Header.Set("Retry-After", "120")
+	if wait := d.wait(0, resp); wait != d.maxWait {
+		t.Errorf("expected Retry-After to be capped at %s, got %s", d.maxWait, wait)
+	}
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if wait := d.wait(0, resp); wait != d.maxWait {
+		t.Errorf("expected Retry-After date to be capped at %s, got %s", d.maxWait, wait)
+	}
+}
+
+func TestRetryTooManyRequests(t *testing.T) {
+	handler, requests := flakyHandler(1, http.StatusTooManyRequests, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(testZoneJSON))
+	}))
+	client := newTestClient(t, handler, WithRetry(3, 10*time.Millisecond))
+
+	// The server rejected the request without processing it, so even a POST
+	// can be sent again.
+	if _, err := client.CreateZone(context.Background(), "localhost", &Zone{Name: "example.net.", Kind: "Native"}); err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 2 {
+		t.Errorf("expected 2 requests, got %d", *requests)
+	}
+}
+
+func TestRetryTooManyRequestsOnly(t *testing.T) {
+	handler, requests := flakyHandler(1, http.StatusTooManyRequests, zoneHandler())
+	client := newTestClient(t, handler, WithTooManyRequestsRetry(3, 10*time.Millisecond))
+
+	if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 2 {
+		t.Errorf("expected 2 requests, got %d", *requests)
+	}
+
+	handler, requests = flakyHandler(1, http.StatusServiceUnavailable, zoneHandler())
+	client = newTestClient(t, handler, WithTooManyRequestsRetry(3, 10*time.Millisecond))
+
+	if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err == nil {
+		t.Error("expected error, got none")
+	}
+	if *requests != 1 {
+		t.Errorf("expected 1 request, got %d", *requests)
+	}
+}
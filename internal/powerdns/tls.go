@@ -0,0 +1,60 @@
+package powerdns
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// TLSOptions configures the TLS connection to the PowerDNS API.
+type TLSOptions struct {
+	// CACert holds PEM encoded certificates used instead of the system's
+	// trust store to verify the server certificate.
+	CACert []byte
+	// ClientCert and ClientKey hold a PEM encoded certificate and key which
+	// are presented to the server for mutual TLS.
+	ClientCert []byte
+	ClientKey  []byte
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+	// ServerName is used to verify the server certificate instead of the
+	// host of the server URL.
+	ServerName string
+}
+
+// WithTLS configures the TLS connection to the server.
+func WithTLS(opts TLSOptions) Option {
+	return func(c *Client) {
+		c.tls = &opts
+	}
+}
+
+// config builds the tls.Config described by o.
+func (o *TLSOptions) config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: o.InsecureSkipVerify,
+		ServerName:         o.ServerName,
+	}
+
+	if len(o.CACert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(o.CACert) {
+			return nil, errors.New("no valid PEM encoded certificate found in CA certificate")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if len(o.ClientCert) > 0 || len(o.ClientKey) > 0 {
+		if len(o.ClientCert) == 0 || len(o.ClientKey) == 0 {
+			return nil, errors.New("client certificate and client key must be set together")
+		}
+		cert, err := tls.X509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,167 @@
+package powerdns
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newTLSTestClient(t *testing.T, server *httptest.Server, opts ...Option) *Client {
+	t.Helper()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := New(context.Background(), "secret", serverURL.Host, "/api/v1", serverURL.Scheme, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func zoneHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testZoneJSON))
+	})
+}
+
+func certPEM(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+// newTestCertificate creates a certificate signed by parent, or a self-signed
+// CA certificate if parent is nil.
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestTLSServerCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(zoneHandler())
+	t.Cleanup(server.Close)
+	caCert := certPEM(server.Certificate())
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{"system trust store", nil, true},
+		{"custom CA", []Option{WithTLS(TLSOptions{CACert: caCert})}, false},
+		{"custom CA and matching server name", []Option{WithTLS(TLSOptions{CACert: caCert, ServerName: "example.com"})}, false},
+		{"custom CA and other server name", []Option{WithTLS(TLSOptions{CACert: caCert, ServerName: "pdns.example.net"})}, true},
+		{"insecure", []Option{WithTLS(TLSOptions{InsecureSkipVerify: true})}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTLSTestClient(t, server, tt.opts...)
+			_, err := client.GetZone(context.Background(), "localhost", "example.net.")
+			if tt.wantErr && err == nil {
+				t.Error("expected TLS error, got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	ca, caKey := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "Test CA"}}, nil, nil)
+	serverCert, serverKey := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "pdns.example.net"},
+		DNSNames:    []string{"pdns.example.net"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	clientCert, clientKey := newTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "terraform"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	clientKeyDER, err := x509.MarshalECPrivateKey(clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: clientKeyDER})
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+	server := httptest.NewUnstartedServer(zoneHandler())
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.Raw}, PrivateKey: serverKey}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	tlsOpts := TLSOptions{CACert: certPEM(ca), ServerName: "pdns.example.net"}
+
+	client := newTLSTestClient(t, server, WithTLS(tlsOpts))
+	if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err == nil {
+		t.Error("expected request without client certificate to fail")
+	}
+
+	tlsOpts.ClientCert = certPEM(clientCert)
+	tlsOpts.ClientKey = clientKeyPEM
+	client = newTLSTestClient(t, server, WithTLS(tlsOpts))
+	if _, err := client.GetZone(context.Background(), "localhost", "example.net."); err != nil {
+		t.Errorf("unexpected error with client certificate: %v", err)
+	}
+}
+
+func TestTLSInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TLSOptions
+	}{
+		{"invalid CA", TLSOptions{CACert: []byte("not a certificate")}},
+		{"client certificate without key", TLSOptions{ClientCert: []byte("cert")}},
+		{"invalid client certificate", TLSOptions{ClientCert: []byte("cert"), ClientKey: []byte("key")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(context.Background(), "secret", "localhost", "/api/v1", "https", WithTLS(tt.opts)); err == nil {
+				t.Error("expected error, got none")
+			}
+		})
+	}
+}
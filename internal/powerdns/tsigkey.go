@@ -0,0 +1,105 @@
+package powerdns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+type TSIGKey struct {
+	ID        string
+	Name      string
+	Algorithm string
+	// Key is the Base64 encoded secret. If it is empty on creation, the
+	// server generates the key material.
+	Key string
+}
+
+func (pdns *Client) CreateTSIGKey(ctx context.Context, serverID string, key *TSIGKey) (*TSIGKey, error) {
+	if key.Name == "" {
+		return nil, errors.New("tsig key name is required")
+	}
+
+	resp, err := pdns.client.CreateTSIGKeyWithResponse(ctx, serverID, transformTSIGKeyToAPI(key))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return transformAPIToTSIGKey(resp.JSON201), nil
+}
+
+func (pdns *Client) GetTSIGKey(ctx context.Context, serverID, keyID string) (*TSIGKey, error) {
+	resp, err := pdns.client.GetTSIGKeyWithResponse(ctx, serverID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return transformAPIToTSIGKey(resp.JSON200), nil
+}
+
+// UpdateTSIGKey changes the given key. Empty fields of key are left
+// unchanged on the server.
+func (pdns *Client) UpdateTSIGKey(ctx context.Context, serverID, keyID string, key *TSIGKey) (*TSIGKey, error) {
+	resp, err := pdns.client.PutTSIGKeyWithResponse(ctx, serverID, keyID, transformTSIGKeyToAPI(key))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return transformAPIToTSIGKey(resp.JSON200), nil
+}
+
+func (pdns *Client) DeleteTSIGKey(ctx context.Context, serverID, keyID string) error {
+	resp, err := pdns.client.DeleteTSIGKeyWithResponse(ctx, serverID, keyID)
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(resp, http.StatusNoContent)
+}
+
+func transformTSIGKeyToAPI(key *TSIGKey) pdnsclient.TSIGKey {
+	result := pdnsclient.TSIGKey{}
+	if key.Name != "" {
+		name := key.Name
+		result.Name = &name
+	}
+	if key.Algorithm != "" {
+		algorithm := key.Algorithm
+		result.Algorithm = &algorithm
+	}
+	if key.Key != "" {
+		secret := key.Key
+		result.Key = &secret
+	}
+
+	return result
+}
+
+func transformAPIToTSIGKey(key *pdnsclient.TSIGKey) *TSIGKey {
+	result := &TSIGKey{}
+	if key.Id != nil {
+		result.ID = *key.Id
+	}
+	if key.Name != nil {
+		result.Name = *key.Name
+	}
+	if key.Algorithm != nil {
+		result.Algorithm = *key.Algorithm
+	}
+	if key.Key != nil {
+		result.Key = *key.Key
+	}
+
+	return result
+}
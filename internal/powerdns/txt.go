@@ -0,0 +1,40 @@
+package powerdns
+
+import "strings"
+
+// maxCharacterStringLength is the maximum length of a single character string
+// in a TXT record, in bytes.
+const maxCharacterStringLength = 255
+
+// QuoteTXT turns a plain text value into TXT record content. The value is
+// split into chunks of at most 255 bytes, which are quoted and escaped.
+func QuoteTXT(value string) string {
+	if value == "" {
+		return quoteCharacterStrings([]string{""})
+	}
+
+	var chunks []string
+	for len(value) > maxCharacterStringLength {
+		chunks = append(chunks, value[:maxCharacterStringLength])
+		value = value[maxCharacterStringLength:]
+	}
+	chunks = append(chunks, value)
+
+	return quoteCharacterStrings(chunks)
+}
+
+// QuoteString quotes and escapes value as a single string, e.g. for the value
+// of a CAA record, which isn't limited to 255 bytes like a character string. Unlike QuoteTXT, long values are not split.
+func QuoteString(value string) string {
+	return quoteCharacterStrings([]string{value})
+}
+
+// UnquoteTXT turns TXT record content back into the plain text value, by
+// unescaping and joining its character strings.
+func UnquoteTXT(content string) (string, error) {
+	strs, err := parseCharacterStrings(strings.TrimSpace(content))
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strs, ""), nil
+}
@@ -0,0 +1,48 @@
+package powerdns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuoteTXT(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"v=spf1 -all", `"v=spf1 -all"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\path`, `"C:\\path"`},
+		{"café", `"caf\195\169"`},
+		{"", `""`},
+		{strings.Repeat("a", 300), `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`},
+	}
+
+	for _, tt := range tests {
+		got := QuoteTXT(tt.value)
+		if got != tt.want {
+			t.Errorf("QuoteTXT(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+		if err := ValidateContent("TXT", got); err != nil {
+			t.Errorf("QuoteTXT(%q) returned invalid content: %v", tt.value, err)
+		}
+		value, err := UnquoteTXT(got)
+		if err != nil {
+			t.Errorf("UnquoteTXT(%q) returned unexpected error: %v", got, err)
+		}
+		if value != tt.value {
+			t.Errorf("UnquoteTXT(QuoteTXT(%q)) = %q", tt.value, value)
+		}
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	value := "mailto:" + strings.Repeat("a", 300) + "@example.net"
+	got := QuoteString(value)
+	if want := `"` + value + `"`; got != want {
+		t.Errorf("QuoteString(%q) = %q, want a single quoted string", value, got)
+	}
+	if got := QuoteString(`say "hi"`); got != `"say \"hi\""` {
+		t.Errorf("QuoteString did not escape quotes, got %q", got)
+	}
+}
@@ -0,0 +1,233 @@
+package powerdns
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	pdnsclient "github.com/gonzolino/terraform-provider-powerdns/internal/powerdns/client"
+)
+
+// ValidateContent checks that content is well-formed for a record of type
+// rrType. Only the common record types are checked, content of other types
+// is left to the server.
+func ValidateContent(rrType, content string) error {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return errors.New("content must not be empty")
+	}
+
+	switch strings.ToUpper(rrType) {
+	case "A":
+		addr, err := netip.ParseAddr(content)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("%q is not an IPv4 address", content)
+		}
+	case "AAAA":
+		addr, err := netip.ParseAddr(content)
+		if err != nil || !addr.Is6() {
+			return fmt.Errorf("%q is not an IPv6 address", content)
+		}
+	case "CNAME", "NS", "PTR":
+		return validateName(content)
+	case "MX":
+		fields, err := splitFields(content, "<preference> <exchange>")
+		if err != nil {
+			return err
+		}
+		if err := validateUint(fields[0], "preference", 16); err != nil {
+			return err
+		}
+		return validateName(fields[1])
+	case "SRV":
+		fields, err := splitFields(content, "<priority> <weight> <port> <target>")
+		if err != nil {
+			return err
+		}
+		for i, name := range []string{"priority", "weight", "port"} {
+			if err := validateUint(fields[i], name, 16); err != nil {
+				return err
+			}
+		}
+		return validateName(fields[3])
+	case "SOA":
+		fields, err := splitFields(content, "<mname> <rname> <serial> <refresh> <retry> <expire> <minimum>")
+		if err != nil {
+			return err
+		}
+		for _, name := range fields[:2] {
+			if err := validateName(name); err != nil {
+				return err
+			}
+		}
+		for i, name := range []string{"serial", "refresh", "retry", "expire", "minimum"} {
+			if err := validateUint(fields[i+2], name, 32); err != nil {
+				return err
+			}
+		}
+	case "TXT", "SPF":
+		return validateCharacterStrings(content)
+	case "CAA":
+		return validateCAA(content)
+	}
+
+	return nil
+}
+
+// ValidateZoneKind checks that kind is one of the zone kinds known to
+// PowerDNS.
+func ValidateZoneKind(kind string) error {
+	if !pdnsclient.ZoneKind(kind).Valid() {
+		return fmt.Errorf("%q is not a valid zone kind, expected one of %q, %q, %q, %q or %q",
+			kind, pdnsclient.Native, pdnsclient.Master, pdnsclient.Slave, pdnsclient.Producer, pdnsclient.Consumer)
+	}
+	return nil
+}
+
+// dnssecAlgorithms lists the mnemonics of the DNSSEC algorithms supported by
+// PowerDNS, in the spelling the server reports them, by algorithm number.
+var dnssecAlgorithms = []struct {
+	number   string
+	mnemonic string
+}{
+	{"5", "RSASHA1"},
+	{"7", "RSASHA1-NSEC3-SHA1"},
+	{"8", "RSASHA256"},
+	{"10", "RSASHA512"},
+	{"13", "ECDSAP256SHA256"},
+	{"14", "ECDSAP384SHA384"},
+	{"15", "ED25519"},
+	{"16", "ED448"},
+}
+
+// ValidateKeyType checks that keyType is one of the cryptokey types known to
+// PowerDNS.
+func ValidateKeyType(keyType string) error {
+	if !pdnsclient.CryptokeyKeytype(keyType).Valid() {
+		return fmt.Errorf("%q is not a valid key type, expected one of %q, %q or %q",
+			keyType, pdnsclient.Ksk, pdnsclient.Zsk, pdnsclient.Csk)
+	}
+	return nil
+}
+
+// ValidateAlgorithm checks that algorithm is the mnemonic of a DNSSEC
+// algorithm supported by PowerDNS. Case is ignored. Algorithm numbers are
+// rejected, because the server reports the mnemonic instead.
+func ValidateAlgorithm(algorithm string) error {
+	mnemonics := make([]string, len(dnssecAlgorithms))
+	for i, a := range dnssecAlgorithms {
+		if strings.EqualFold(algorithm, a.mnemonic) {
+			return nil
+		}
+		if algorithm == a.number {
+			return fmt.Errorf("use the mnemonic %q instead of the algorithm number %s", a.mnemonic, a.number)
+		}
+		mnemonics[i] = a.mnemonic
+	}
+	return fmt.Errorf("%q is not a supported DNSSEC algorithm, expected one of %s", algorithm, strings.Join(mnemonics, ", "))
+}
+
+// ValidateFQDN checks that name is a syntactically valid, fully qualified
+// domain name with a trailing dot, as PowerDNS expects it for zone and record
+// set names.
+func ValidateFQDN(name string) error {
+	if !strings.HasSuffix(name, ".") {
+		return fmt.Errorf("%q must be fully qualified and end with a dot", name)
+	}
+	return validateName(name)
+}
+
+// IsInZone reports whether name is the apex of zone or a name below it. The
+// comparison ignores case and trailing dots.
+func IsInZone(name, zone string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	return zone == "" || name == zone || strings.HasSuffix(name, "."+zone)
+}
+
+// splitFields splits content into whitespace separated fields, which must
+// match the fields of format.
+func splitFields(content, format string) ([]string, error) {
+	fields := strings.Fields(content)
+	if len(fields) != len(strings.Fields(format)) {
+		return nil, fmt.Errorf("%q does not have the format %s", content, format)
+	}
+	return fields, nil
+}
+
+func validateUint(value, name string, bits int) error {
+	if _, err := strconv.ParseUint(value, 10, bits); err != nil {
+		return fmt.Errorf("%s %q is not a number between 0 and %d", name, value, uint64(1)<<bits-1)
+	}
+	return nil
+}
+
+// validateName checks that name is a syntactically valid domain name. The
+// root name "." is valid, too.
+func validateName(name string) error {
+	if name == "." {
+		return nil
+	}
+	if strings.ContainsAny(name, " \t\"") {
+		return fmt.Errorf("%q is not a valid domain name", name)
+	}
+	if len(strings.TrimSuffix(name, ".")) > 253 {
+		return fmt.Errorf("domain name %q is longer than 253 characters", name)
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if label == "" {
+			return fmt.Errorf("domain name %q contains an empty label", name)
+		}
+		if len(label) > 63 {
+			return fmt.Errorf("label %q of domain name %q is longer than 63 characters", label, name)
+		}
+	}
+	return nil
+}
+
+func validateCharacterStrings(content string) error {
+	if !strings.HasPrefix(content, `"`) {
+		return fmt.Errorf("%q must be enclosed in double quotes", content)
+	}
+	strs, err := parseCharacterStrings(content)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid character string: %v", content, err)
+	}
+	for _, s := range strs {
+		if len(s) > 255 {
+			return fmt.Errorf("character string %q is longer than 255 bytes, split it into multiple quoted strings", s)
+		}
+	}
+	return nil
+}
+
+// validateCAA checks content of a CAA record, which has the form
+// <flags> <tag> "<value>".
+func validateCAA(content string) error {
+	fields := strings.SplitN(content, " ", 3)
+	if len(fields) != 3 {
+		return fmt.Errorf("%q does not have the format <flags> <tag> \"<value>\"", content)
+	}
+	if err := validateUint(fields[0], "flags", 8); err != nil {
+		return err
+	}
+	tag := fields[1]
+	if tag == "" || len(tag) > 15 {
+		return fmt.Errorf("tag %q must have between 1 and 15 characters", tag)
+	}
+	for _, c := range tag {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return fmt.Errorf("tag %q may only contain letters and digits", tag)
+		}
+	}
+	if !strings.HasPrefix(fields[2], `"`) {
+		return fmt.Errorf("value %q must be enclosed in double quotes", fields[2])
+	}
+	strs, err := parseCharacterStrings(fields[2])
+	if err != nil || len(strs) != 1 {
+		return fmt.Errorf("value %q must be a single quoted string", fields[2])
+	}
+	return nil
+}
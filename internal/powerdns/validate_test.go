@@ -0,0 +1,143 @@
+package powerdns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateContent(t *testing.T) {
+	tests := []struct {
+		rrType  string
+		content string
+		valid   bool
+	}{
+		{"A", "192.0.2.1", true},
+		{"A", "2001:db8::1", false},
+		{"A", "192.0.2", false},
+		{"AAAA", "2001:db8::1", true},
+		{"AAAA", "::ffff:192.0.2.1", true},
+		{"AAAA", "192.0.2.1", false},
+		{"CNAME", "www.example.net.", true},
+		{"CNAME", "www..example.net.", false},
+		{"CNAME", "www example.net.", false},
+		{"MX", "10 mail.example.net.", true},
+		{"MX", "mail.example.net.", false},
+		{"MX", "70000 mail.example.net.", false},
+		{"SRV", "0 5 5060 sip.example.net.", true},
+		{"SRV", "0 0 0 .", true},
+		{"SRV", "0 5 sip.example.net.", false},
+		{"SOA", "ns1.example.net. hostmaster.example.net. 1 10800 3600 604800 3600", true},
+		{"SOA", "ns1.example.net. hostmaster.example.net. 1 10800 3600 604800", false},
+		{"TXT", `"v=spf1 -all"`, true},
+		{"TXT", `"foo" "bar"`, true},
+		{"TXT", `v=spf1 -all`, false},
+		{"TXT", `"unterminated`, false},
+		{"CAA", `0 issue "letsencrypt.org"`, true},
+		{"CAA", `0 iss-ue "letsencrypt.org"`, false},
+		{"CAA", `256 issue "letsencrypt.org"`, false},
+		{"CAA", `0 issue letsencrypt.org`, false},
+		{"CAA", `0 iodef "mailto:` + strings.Repeat("a", 240) + `@example.net"`, true},
+		{"LOC", "anything goes", true},
+		{"A", "", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateContent(tt.rrType, tt.content)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateContent(%q, %q) returned unexpected error: %v", tt.rrType, tt.content, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("ValidateContent(%q, %q) expected error, got none", tt.rrType, tt.content)
+		}
+	}
+}
+
+func TestValidateZoneKind(t *testing.T) {
+	for _, kind := range []string{"Native", "Master", "Slave", "Producer", "Consumer"} {
+		if err := ValidateZoneKind(kind); err != nil {
+			t.Errorf("ValidateZoneKind(%q) returned unexpected error: %v", kind, err)
+		}
+	}
+	for _, kind := range []string{"", "native", "Primary", "Foo"} {
+		if err := ValidateZoneKind(kind); err == nil {
+			t.Errorf("ValidateZoneKind(%q) expected error, got none", kind)
+		}
+	}
+}
+
+func TestValidateKeyType(t *testing.T) {
+	for _, keyType := range []string{"ksk", "zsk", "csk"} {
+		if err := ValidateKeyType(keyType); err != nil {
+			t.Errorf("ValidateKeyType(%q) returned unexpected error: %v", keyType, err)
+		}
+	}
+	for _, keyType := range []string{"", "KSK", "key"} {
+		if err := ValidateKeyType(keyType); err == nil {
+			t.Errorf("ValidateKeyType(%q) expected error, got none", keyType)
+		}
+	}
+}
+
+func TestValidateAlgorithm(t *testing.T) {
+	for _, algorithm := range []string{"ECDSAP256SHA256", "ecdsap256sha256", "RSASHA1-NSEC3-SHA1", "ED25519"} {
+		if err := ValidateAlgorithm(algorithm); err != nil {
+			t.Errorf("ValidateAlgorithm(%q) returned unexpected error: %v", algorithm, err)
+		}
+	}
+	for _, algorithm := range []string{"", "13", "ecdsa256", "RSAMD5"} {
+		if err := ValidateAlgorithm(algorithm); err == nil {
+			t.Errorf("ValidateAlgorithm(%q) expected error, got none", algorithm)
+		}
+	}
+	if err := ValidateAlgorithm("13"); err == nil || !strings.Contains(err.Error(), "ECDSAP256SHA256") {
+		t.Errorf("expected error for algorithm number to name the mnemonic, got %v", err)
+	}
+}
+
+func TestValidateFQDN(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"example.net.", true},
+		{"www.example.net.", true},
+		{"_sip._tcp.example.net.", true},
+		{".", true},
+		{"example.net", false},
+		{"www..example.net.", false},
+		{"www example.net.", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateFQDN(tt.name)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateFQDN(%q) returned unexpected error: %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("ValidateFQDN(%q) expected error, got none", tt.name)
+		}
+	}
+}
+
+func TestIsInZone(t *testing.T) {
+	tests := []struct {
+		name string
+		zone string
+		want bool
+	}{
+		{"example.net.", "example.net.", true},
+		{"www.example.net.", "example.net.", true},
+		{"WWW.Example.NET.", "example.net", true},
+		{"www.example.org.", "example.net.", false},
+		{"wwwexample.net.", "example.net.", false},
+		{"example.net.", "www.example.net.", false},
+		{"www.example.net.", ".", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsInZone(tt.name, tt.zone); got != tt.want {
+			t.Errorf("IsInZone(%q, %q) = %v, want %v", tt.name, tt.zone, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package powerdns
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// keyedLocks serializes operations on the same key. It is used per zone, as
+// Terraform applies resources in parallel and concurrent PATCH requests for a
+// single zone race on the SOA serial bump in some PowerDNS backends, and per
+// record set for read-modify-write updates of single records.
+type keyedLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sem  chan struct{}
+	refs int
+}
+
+// lock blocks until the caller holds the lock for the given key or ctx is
+// done. On success the returned function must be called to release the lock.
+func (l *keyedLocks) lock(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*keyedLock)
+	}
+	zl, ok := l.locks[key]
+	if !ok {
+		zl = &keyedLock{sem: make(chan struct{}, 1)}
+		l.locks[key] = zl
+	}
+	zl.refs++
+	l.mu.Unlock()
+
+	release := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		zl.refs--
+		if zl.refs == 0 {
+			delete(l.locks, key)
+		}
+	}
+
+	select {
+	case zl.sem <- struct{}{}:
+		return func() {
+			<-zl.sem
+			release()
+		}, nil
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+}
+
+// zoneLockKey identifies a zone independent of the case and trailing dot
+// used in its id.
+func zoneLockKey(serverID, zoneID string) string {
+	return serverID + "/" + strings.ToLower(strings.TrimSuffix(zoneID, "."))
+}
+
+// rrsetLockKey identifies a record set independent of the case and trailing
+// dot used in the zone id and record set name.
+func rrsetLockKey(serverID, zoneID, name, rrType string) string {
+	return zoneLockKey(serverID, zoneID) + "/" + strings.ToLower(strings.TrimSuffix(name, ".")) + "/" + strings.ToUpper(rrType)
+}
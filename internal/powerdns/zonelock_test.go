@@ -0,0 +1,74 @@
+package powerdns
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPatchZoneSerialized(t *testing.T) {
+	var inFlight, maxInFlight, patches int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		atomic.AddInt32(&patches, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recordSet := &RecordSet{Name: "www.example.net.", Type: "A", TTL: 300, Records: []Record{{Content: "192.0.2.1"}}}
+			// Zone ids differing in case and trailing dot refer to the same zone.
+			zoneID := "example.net."
+			if i%2 == 0 {
+				zoneID = "Example.NET"
+			}
+			if err := client.UpdateRecordSet(context.Background(), "localhost", zoneID, recordSet); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if patches != 10 {
+		t.Errorf("expected 10 PATCH requests, got %d", patches)
+	}
+	if maxInFlight != 1 {
+		t.Errorf("expected PATCH requests to be serialized, got %d concurrent requests", maxInFlight)
+	}
+	if len(client.zoneLocks.locks) != 0 {
+		t.Errorf("expected all zone locks to be released, got %d", len(client.zoneLocks.locks))
+	}
+}
+
+func TestZoneLockContextCanceled(t *testing.T) {
+	var locks keyedLocks
+
+	unlock, err := locks.lock(context.Background(), zoneLockKey("localhost", "example.net."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := locks.lock(ctx, zoneLockKey("localhost", "example.net.")); err == nil {
+		t.Fatal("expected lock to fail when the context is done")
+	}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &AutoprimariesDataSource{}
+
+func NewAutoprimariesDataSource() datasource.DataSource {
+	return &AutoprimariesDataSource{}
+}
+
+// AutoprimariesDataSource defines the data source implementation.
+type AutoprimariesDataSource struct {
+	client *powerdns.Client
+}
+
+// AutoprimariesDataSourceModel describes the data source data model.
+type AutoprimariesDataSourceModel struct {
+	Id            types.String            `tfsdk:"id"`
+	ServerId      types.String            `tfsdk:"server_id"`
+	Autoprimaries []AutoprimaryEntryModel `tfsdk:"autoprimaries"`
+}
+
+// AutoprimaryEntryModel describes a single autoprimary of the list.
+type AutoprimaryEntryModel struct {
+	IP         types.String `tfsdk:"ip"`
+	Nameserver types.String `tfsdk:"nameserver"`
+	Account    types.String `tfsdk:"account"`
+}
+
+func (d *AutoprimariesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autoprimaries"
+}
+
+func (d AutoprimariesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "All PowerDNS Autoprimaries of a server",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "State ID for the data source (only needed for internal technical purposes).",
+				Computed:            true,
+			},
+			"server_id": serverIDDataSourceAttribute(),
+			"autoprimaries": schema.ListNestedAttribute{
+				MarkdownDescription: "The autoprimaries of the server.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "IP address of the primary server.",
+							Computed:            true,
+						},
+						"nameserver": schema.StringAttribute{
+							MarkdownDescription: "DNS name of the primary server.",
+							Computed:            true,
+						},
+						"account": schema.StringAttribute{
+							MarkdownDescription: "Account which is set on zones created through this autoprimary.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AutoprimariesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerdns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *powerdns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d AutoprimariesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AutoprimariesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ServerId = serverIDOrDefault(d.client, data.ServerId)
+	serverId := data.ServerId.ValueString()
+	tflog.Debug(ctx, "Reading autoprimaries", map[string]interface{}{
+		"server_id": serverId,
+	})
+	autoprimaries, err := d.client.GetAutoprimaries(ctx, serverId)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get autoprimaries of server '%s': %v", serverId, err))
+		return
+	}
+
+	data.Id = types.StringValue(serverId)
+	data.Autoprimaries = make([]AutoprimaryEntryModel, 0, len(autoprimaries))
+	for _, autoprimary := range autoprimaries {
+		data.Autoprimaries = append(data.Autoprimaries, AutoprimaryEntryModel{
+			IP:         types.StringValue(autoprimary.IP),
+			Nameserver: types.StringValue(autoprimary.Nameserver),
+			Account:    types.StringValue(autoprimary.Account),
+		})
+	}
+	tflog.Debug(ctx, "Read autoprimaries", map[string]interface{}{
+		"server_id": serverId,
+		"count":     len(autoprimaries),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsAutoprimariesDataSource(t *testing.T) {
+	nameserver := "ns1." + strings.TrimSuffix(randomZoneName(12), ".")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccPowerdnsAutoprimariesDataSourceConfig("192.0.2.54", nameserver),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_autoprimaries.test", "server_id", "localhost"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.powerdns_autoprimaries.test", "autoprimaries.*", map[string]string{
+						"ip":         "192.0.2.54",
+						"nameserver": nameserver,
+						"account":    "ops",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccPowerdnsAutoprimariesDataSourceConfig(ip, nameserver string) string {
+	return fmt.Sprintf(`
+resource "powerdns_autoprimary" "test" {
+  server_id = "localhost"
+  ip = %[1]q
+  nameserver = %[2]q
+  account = "ops"
+}
+
+data "powerdns_autoprimaries" "test" {
+  server_id = "localhost"
+
+  depends_on = [powerdns_autoprimary.test]
+}
+`, ip, nameserver)
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &AutoprimaryResource{}
+var _ resource.ResourceWithImportState = &AutoprimaryResource{}
+var _ resource.ResourceWithModifyPlan = &AutoprimaryResource{}
+
+func NewAutoprimaryResource() resource.Resource {
+	return &AutoprimaryResource{}
+}
+
+type AutoprimaryResource struct {
+	client *powerdns.Client
+}
+
+type AutoprimaryResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	ServerId   types.String `tfsdk:"server_id"`
+	IP         types.String `tfsdk:"ip"`
+	Nameserver types.String `tfsdk:"nameserver"`
+	Account    types.String `tfsdk:"account"`
+}
+
+func (r *AutoprimaryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autoprimary"
+}
+
+func (r *AutoprimaryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "PowerDNS Autoprimary. Secondary zones for which a NOTIFY from this primary is received are created automatically.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "State ID for the autoprimary (only needed for internal technical purposes).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_id": serverIDAttribute(),
+			"ip": schema.StringAttribute{
+				MarkdownDescription: "IP address of the primary server.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"nameserver": schema.StringAttribute{
+				MarkdownDescription: "DNS name of the primary server, which must be listed as NS record in the zones it notifies about.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account": schema.StringAttribute{
+				MarkdownDescription: "Account which is set on zones created through this autoprimary.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AutoprimaryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.client, req, resp)
+}
+
+func (r *AutoprimaryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerdns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *powerdns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AutoprimaryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AutoprimaryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	autoprimary := &powerdns.Autoprimary{
+		IP:         data.IP.ValueString(),
+		Nameserver: data.Nameserver.ValueString(),
+		Account:    data.Account.ValueString(),
+	}
+
+	serverId := data.ServerId.ValueString()
+	tflog.Debug(ctx, "Creating autoprimary", map[string]interface{}{
+		"server_id":  serverId,
+		"ip":         autoprimary.IP,
+		"nameserver": autoprimary.Nameserver,
+	})
+	if err := r.client.CreateAutoprimary(ctx, serverId, autoprimary); err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create autoprimary '%s/%s': %v", autoprimary.IP, autoprimary.Nameserver, err))
+		return
+	}
+	tflog.Debug(ctx, "Created autoprimary", map[string]interface{}{
+		"server_id":  serverId,
+		"ip":         autoprimary.IP,
+		"nameserver": autoprimary.Nameserver,
+	})
+
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s", autoprimary.IP, autoprimary.Nameserver))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AutoprimaryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AutoprimaryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverId := data.ServerId.ValueString()
+	ip := data.IP.ValueString()
+	nameserver := data.Nameserver.ValueString()
+	tflog.Debug(ctx, "Reading autoprimary", map[string]interface{}{
+		"server_id":  serverId,
+		"ip":         ip,
+		"nameserver": nameserver,
+	})
+	autoprimary, err := r.client.GetAutoprimary(ctx, serverId, ip, nameserver)
+	if powerdns.IsNotFound(err) {
+		tflog.Warn(ctx, "Autoprimary not found, removing it from state", map[string]interface{}{
+			"server_id":  serverId,
+			"ip":         ip,
+			"nameserver": nameserver,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get autoprimary '%s/%s': %v", ip, nameserver, err))
+		return
+	}
+
+	// ip and nameserver keep their configured spelling, the server may print
+	// them differently (e.g. compressed IPv6 addresses).
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s", ip, nameserver))
+	data.Account = types.StringValue(autoprimary.Account)
+	tflog.Debug(ctx, "Read autoprimary", map[string]interface{}{
+		"server_id":  serverId,
+		"ip":         ip,
+		"nameserver": nameserver,
+		"account":    autoprimary.Account,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AutoprimaryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All attributes require replacement, the API has no way to modify an
+	// autoprimary in place.
+	var data AutoprimaryResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AutoprimaryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AutoprimaryResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverId := data.ServerId.ValueString()
+	ip := data.IP.ValueString()
+	nameserver := data.Nameserver.ValueString()
+	tflog.Debug(ctx, "Deleting autoprimary", map[string]interface{}{
+		"server_id":  serverId,
+		"ip":         ip,
+		"nameserver": nameserver,
+	})
+	// The server only deletes autoprimaries that are addressed with its own
+	// spelling of ip and nameserver.
+	autoprimary, err := r.client.GetAutoprimary(ctx, serverId, ip, nameserver)
+	if err == nil {
+		err = r.client.DeleteAutoprimary(ctx, serverId, autoprimary.IP, autoprimary.Nameserver)
+	}
+	if err != nil && !powerdns.IsNotFound(err) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete autoprimary '%s/%s': %v", ip, nameserver, err))
+		return
+	}
+	tflog.Debug(ctx, "Deleted autoprimary", map[string]interface{}{
+		"server_id":  serverId,
+		"ip":         ip,
+		"nameserver": nameserver,
+	})
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *AutoprimaryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fields, err := parseImportID(req.ID, importServerID(r.client), "server_id", "ip", "nameserver")
+	if err != nil {
+		resp.Diagnostics.AddError("Resource Import ID invalid", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip"), fields[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("nameserver"), fields[2])...)
+}
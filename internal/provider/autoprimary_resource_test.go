@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsAutoprimaryResource(t *testing.T) {
+	nameserver := "ns1." + strings.TrimSuffix(randomZoneName(12), ".")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsAutoprimaryResourceConfig("192.0.2.53", nameserver, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_autoprimary.test", "server_id", "localhost"),
+					resource.TestCheckResourceAttr("powerdns_autoprimary.test", "ip", "192.0.2.53"),
+					resource.TestCheckResourceAttr("powerdns_autoprimary.test", "nameserver", nameserver),
+					resource.TestCheckResourceAttr("powerdns_autoprimary.test", "account", ""),
+					resource.TestCheckResourceAttr("powerdns_autoprimary.test", "id", "192.0.2.53/"+nameserver),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerdns_autoprimary.test",
+				ImportStateId:     fmt.Sprintf("localhost/192.0.2.53/%s", nameserver),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace and Read testing
+			{
+				Config: testAccPowerdnsAutoprimaryResourceConfig("192.0.2.53", nameserver, "ops"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_autoprimary.test", "account", "ops"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsAutoprimaryResourceConfig(ip, nameserver, account string) string {
+	return fmt.Sprintf(`
+resource "powerdns_autoprimary" "test" {
+  server_id = "localhost"
+  ip = %[1]q
+  nameserver = %[2]q
+  account = %[3]q
+}
+`, ip, nameserver, account)
+}
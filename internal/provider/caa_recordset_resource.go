@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var caaRecordAttrTypes = map[string]attr.Type{
+	"flags": types.Int64Type,
+	"tag":   types.StringType,
+	"value": types.StringType,
+}
+
+func NewCAARecordsetResource() resource.Resource {
+	return &TypedRecordsetResource{
+		typedRecordset: typedRecordset{
+			rrType:   "CAA",
+			typeName: "_caa_recordset",
+			records: schema.SetNestedAttribute{
+				MarkdownDescription: "All CAA records in this record set.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"flags": schema.Int64Attribute{
+							MarkdownDescription: "Flags of the record, 128 marks the property as critical.",
+							Required:            true,
+						},
+						"tag": schema.StringAttribute{
+							MarkdownDescription: "Property tag (e.g. \"issue\", \"issuewild\" or \"iodef\").",
+							Required:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Unquoted property value (e.g. \"letsencrypt.org\").",
+							Required:            true,
+						},
+					},
+				},
+			},
+			elementType: types.ObjectType{AttrTypes: caaRecordAttrTypes},
+			toContent: func(v attr.Value) string {
+				attrs := v.(types.Object).Attributes()
+				return fmt.Sprintf("%d %s %s",
+					attrs["flags"].(types.Int64).ValueInt64(),
+					attrs["tag"].(types.String).ValueString(),
+					powerdns.QuoteString(attrs["value"].(types.String).ValueString()))
+			},
+			fromContent: func(content string) (attr.Value, error) {
+				fields := strings.SplitN(strings.TrimSpace(content), " ", 3)
+				if len(fields) != 3 {
+					return nil, fmt.Errorf("expected <flags> <tag> \"<value>\"")
+				}
+				flags, err := strconv.ParseInt(fields[0], 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				value, err := powerdns.UnquoteTXT(fields[2])
+				if err != nil {
+					return nil, err
+				}
+				return types.ObjectValueMust(caaRecordAttrTypes, map[string]attr.Value{
+					"flags": types.Int64Value(flags),
+					"tag":   types.StringValue(fields[1]),
+					"value": types.StringValue(value),
+				}), nil
+			},
+		},
+	}
+}
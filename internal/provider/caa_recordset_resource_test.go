@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsCAARecordsetResource(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsCAARecordsetResourceConfig(recordsetName, "letsencrypt.org"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_caa_recordset.test", "records.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_caa_recordset.test", "records.*", map[string]string{
+						"flags": "0",
+						"tag":   "issue",
+						"value": "letsencrypt.org",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_caa_recordset.test", "records.*", map[string]string{
+						"flags": "0",
+						"tag":   "iodef",
+						"value": "mailto:security@example.net",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerdns_caa_recordset.test",
+				ImportStateId:     fmt.Sprintf("localhost/example.net./%s", recordsetName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPowerdnsCAARecordsetResourceConfig(recordsetName, "pki.goog"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_caa_recordset.test", "records.*", map[string]string{
+						"tag":   "issue",
+						"value": "pki.goog",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsCAARecordsetResourceConfig(name, issuer string) string {
+	return fmt.Sprintf(`
+resource "powerdns_caa_recordset" "test" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  ttl = 300
+  records = [
+    { flags = 0, tag = "issue", value = %[2]q },
+    { flags = 0, tag = "iodef", value = "mailto:security@example.net" },
+  ]
+}
+`, name, issuer)
+}
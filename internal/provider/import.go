@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseImportID splits an import ID into the fields named by format. Fields
+// are separated by "/", a backslash escapes the next character, so "\/" is a
+// slash within a field. If format starts with "server_id", that field may be
+// omitted and defaults to serverID.
+func parseImportID(id, serverID string, format ...string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	escaped := false
+	for _, c := range id {
+		switch {
+		case escaped:
+			field.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '/':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteRune(c)
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("ID '%s' ends with an incomplete escape sequence", id)
+	}
+	fields = append(fields, field.String())
+
+	optionalServerID := len(format) > 0 && format[0] == "server_id"
+	if optionalServerID && len(fields) == len(format)-1 {
+		fields = append([]string{serverID}, fields...)
+	}
+
+	if len(fields) != len(format) {
+		formatDesc := strings.Join(format, "/")
+		if optionalServerID {
+			formatDesc = "[server_id/]" + strings.Join(format[1:], "/")
+		}
+		return nil, fmt.Errorf("ID '%s' should be in format '%s', slashes within a field have to be escaped as '\\/'", id, formatDesc)
+	}
+	for i, f := range fields {
+		if f == "" {
+			return nil, fmt.Errorf("%s in ID '%s' must not be empty", format[i], id)
+		}
+	}
+
+	return fields, nil
+}
+
+// formatImportID joins fields into an import ID which parseImportID splits
+// into the same fields again, escaping backslashes and slashes within them.
+func formatImportID(fields ...string) string {
+	escaped := make([]string, len(fields))
+	for i, f := range fields {
+		escaped[i] = importIDEscaper.Replace(f)
+	}
+	return strings.Join(escaped, "/")
+}
+
+var importIDEscaper = strings.NewReplacer(`\`, `\\`, "/", `\/`)
+
+// importZoneID turns the zone name or id given in an import ID into a zone
+// id. The trailing dot may be omitted, and slashes, e.g. in RFC 2317 reverse
+// zones, are escaped the way PowerDNS does it in zone ids.
+func importZoneID(zone string) string {
+	if strings.Contains(zone, "=") {
+		// Already an id with escaped characters.
+		return zone
+	}
+	return strings.ReplaceAll(importName(zone), "/", "=2F")
+}
+
+// importName adds the trailing dot to a name given in an import ID if it is
+// missing.
+func importName(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	format := []string{"server_id", "zone_id", "name", "type"}
+	tests := []struct {
+		id      string
+		want    []string
+		wantErr bool
+	}{
+		{"localhost/example.net./www.example.net./A", []string{"localhost", "example.net.", "www.example.net.", "A"}, false},
+		{"example.net./www.example.net./A", []string{"localhost", "example.net.", "www.example.net.", "A"}, false},
+		{`0\/26.2.0.192.in-addr.arpa./10.0\/26.2.0.192.in-addr.arpa./PTR`, []string{"localhost", "0/26.2.0.192.in-addr.arpa.", "10.0/26.2.0.192.in-addr.arpa.", "PTR"}, false},
+		{`other/example.net./back\\slash.example.net./TXT`, []string{"other", "example.net.", `back\slash.example.net.`, "TXT"}, false},
+		{"www.example.net./A", nil, true},
+		{"localhost/example.net./www.example.net./A/extra", nil, true},
+		{"localhost//www.example.net./A", nil, true},
+		{`example.net./www.example.net./A\`, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseImportID(tt.id, "localhost", format...)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseImportID(%q) expected error, got %q", tt.id, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseImportID(%q) returned unexpected error: %v", tt.id, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseImportID(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestFormatImportID(t *testing.T) {
+	format := []string{"server_id", "zone_id", "name", "type", "content"}
+	tests := []struct {
+		fields []string
+		want   string
+	}{
+		{[]string{"localhost", "example.net.", "www.example.net.", "A", "192.0.2.1"}, "localhost/example.net./www.example.net./A/192.0.2.1"},
+		{[]string{"localhost", "example.net.", "example.net.", "TXT", `"v=spf1 ip4:10.0.0.0/8 -all"`}, `localhost/example.net./example.net./TXT/"v=spf1 ip4:10.0.0.0\/8 -all"`},
+		{[]string{"localhost", "0=2F26.2.0.192.in-addr.arpa.", "10.0/26.2.0.192.in-addr.arpa.", "PTR", "host.example.net."}, `localhost/0=2F26.2.0.192.in-addr.arpa./10.0\/26.2.0.192.in-addr.arpa./PTR/host.example.net.`},
+		{[]string{"localhost", "example.net.", "example.net.", "TXT", `"back\\slash\/"`}, `localhost/example.net./example.net./TXT/"back\\\\slash\\\/"`},
+	}
+
+	for _, tt := range tests {
+		id := formatImportID(tt.fields...)
+		if id != tt.want {
+			t.Errorf("formatImportID(%q) = %q, want %q", tt.fields, id, tt.want)
+		}
+		got, err := parseImportID(id, "other", format...)
+		if err != nil {
+			t.Errorf("parseImportID(%q) returned unexpected error: %v", id, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.fields) {
+			t.Errorf("parseImportID(formatImportID(%q)) = %q", tt.fields, got)
+		}
+	}
+}
+
+func TestImportZoneID(t *testing.T) {
+	tests := []struct {
+		zone string
+		want string
+	}{
+		{"example.net.", "example.net."},
+		{"example.net", "example.net."},
+		{"0/26.2.0.192.in-addr.arpa", "0=2F26.2.0.192.in-addr.arpa."},
+		{"0=2F26.2.0.192.in-addr.arpa.", "0=2F26.2.0.192.in-addr.arpa."},
+	}
+
+	for _, tt := range tests {
+		if got := importZoneID(tt.zone); got != tt.want {
+			t.Errorf("importZoneID(%q) = %q, want %q", tt.zone, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var mxRecordAttrTypes = map[string]attr.Type{
+	"priority": types.Int64Type,
+	"exchange": types.StringType,
+}
+
+func NewMXRecordsetResource() resource.Resource {
+	return &TypedRecordsetResource{
+		typedRecordset: typedRecordset{
+			rrType:   "MX",
+			typeName: "_mx_recordset",
+			records: schema.SetNestedAttribute{
+				MarkdownDescription: "All MX records in this record set.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"priority": schema.Int64Attribute{
+							MarkdownDescription: "Preference of the mail exchange, lower values are preferred.",
+							Required:            true,
+						},
+						"exchange": schema.StringAttribute{
+							MarkdownDescription: "Hostname of the mail exchange (e.g. \"mail.example.com.\").",
+							Required:            true,
+						},
+					},
+				},
+			},
+			elementType: types.ObjectType{AttrTypes: mxRecordAttrTypes},
+			toContent: func(v attr.Value) string {
+				attrs := v.(types.Object).Attributes()
+				return fmt.Sprintf("%d %s",
+					attrs["priority"].(types.Int64).ValueInt64(),
+					attrs["exchange"].(types.String).ValueString())
+			},
+			fromContent: func(content string) (attr.Value, error) {
+				fields := strings.Fields(content)
+				if len(fields) != 2 {
+					return nil, fmt.Errorf("expected <priority> <exchange>")
+				}
+				priority, err := strconv.ParseInt(fields[0], 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				return types.ObjectValueMust(mxRecordAttrTypes, map[string]attr.Value{
+					"priority": types.Int64Value(priority),
+					"exchange": types.StringValue(fields[1]),
+				}), nil
+			},
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsMXRecordsetResource(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccPowerdnsMXRecordsetResourceConfig(recordsetName, 70000, "mail.example.net."),
+				ExpectError: regexp.MustCompile(`Record is not valid for a record of type MX`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsMXRecordsetResourceConfig(recordsetName, 10, "Mail.example.net"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_mx_recordset.test", "name", recordsetName),
+					resource.TestCheckResourceAttr("powerdns_mx_recordset.test", "records.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_mx_recordset.test", "records.*", map[string]string{
+						"priority": "10",
+						"exchange": "Mail.example.net",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerdns_mx_recordset.test",
+				ImportStateId:     fmt.Sprintf("localhost/example.net./%s", recordsetName),
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The server returns the exchange in its canonical form.
+				ImportStateVerifyIgnore: []string{"records"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccPowerdnsMXRecordsetResourceConfig(recordsetName, 20, "mail2.example.net."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_mx_recordset.test", "records.*", map[string]string{
+						"priority": "20",
+						"exchange": "mail2.example.net.",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsMXRecordsetResourceConfig(name string, priority int64, exchange string) string {
+	return fmt.Sprintf(`
+resource "powerdns_mx_recordset" "test" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  ttl = 300
+  records = [
+    { priority = %[2]d, exchange = %[3]q },
+  ]
+}
+`, name, priority, exchange)
+}
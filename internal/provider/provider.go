@@ -5,15 +5,31 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// defaultMaxRetries is the number of retries of failed requests unless
+// max_retries is set. Retries are opt-in, so failed requests fail the apply
+// right away as before.
+const defaultMaxRetries = 0
+
+// tooManyRequestsRetries is the number of retries of requests rejected with
+// 429 Too Many Requests if the request rate or concurrency is limited, but
+// max_retries is 0. A reverse proxy enforcing the same limits may still
+// reject single requests, which shouldn't fail the apply.
+const tooManyRequestsRetries = 3
+
 // Ensure PowerdnsProvider satisfies various provider interfaces.
 var _ provider.Provider = &PowerdnsProvider{}
 
@@ -27,8 +43,23 @@ type PowerdnsProvider struct {
 
 // PowerdnsProviderModel describes the provider data model.
 type PowerdnsProviderModel struct {
-	APIKey    types.String `tfsdk:"api_key"`
-	ServerURL types.String `tfsdk:"server_url"`
+	APIKey      types.String `tfsdk:"api_key"`
+	ServerURL   types.String `tfsdk:"server_url"`
+	BatchWindow types.String `tfsdk:"batch_window"`
+	ServerID    types.String `tfsdk:"server_id"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait types.String `tfsdk:"retry_max_wait"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+
+	CACert             types.String `tfsdk:"ca_cert"`
+	CAFile             types.String `tfsdk:"ca_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	TLSServerName      types.String `tfsdk:"tls_server_name"`
 }
 
 func (p *PowerdnsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -49,6 +80,55 @@ func (p *PowerdnsProvider) Schema(_ context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "PowerDNS server URL. Can be set via environment variable `POWERDNS_SERVER_URL`.",
 				Optional:            true,
 			},
+			"server_id": schema.StringAttribute{
+				MarkdownDescription: "Default id of the server for all resources and data sources which don't set `server_id` themselves. Defaults to `\"localhost\"`. Can be set via environment variable `POWERDNS_SERVER_ID`.",
+				Optional:            true,
+			},
+			"ca_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates used to verify the server certificate instead of the system's trust store. Conflicts with `ca_file`. Can be set via environment variable `POWERDNS_CA_CERT`.",
+				Optional:            true,
+			},
+			"ca_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with PEM encoded CA certificates used to verify the server certificate instead of the system's trust store. Conflicts with `ca_cert`. Can be set via environment variable `POWERDNS_CA_FILE`.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Requires `client_key`. Can be set via environment variable `POWERDNS_CLIENT_CERT`.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate. Requires `client_cert`. Can be set via environment variable `POWERDNS_CLIENT_KEY`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disable verification of the server certificate. Only use this for testing. Can be set via environment variable `POWERDNS_INSECURE_SKIP_VERIFY`.",
+				Optional:            true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				MarkdownDescription: "Server name used to verify the server certificate, if it differs from the host of `server_url`. Can be set via environment variable `POWERDNS_TLS_SERVER_NAME`.",
+				Optional:            true,
+			},
+			"batch_window": schema.StringAttribute{
+				MarkdownDescription: "Duration (e.g. `\"200ms\"`) during which record set changes for the same zone are collected and sent to the server in a single request. Batching is disabled if unset. Can be set via environment variable `POWERDNS_BATCH_WINDOW`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries of a request that failed with a transient error, like a 5xx or 429 response, a reset connection or a timeout. Requests that can't safely be sent twice are only retried if the server did not process them. Defaults to `0`, which disables retries, except for up to 3 retries of 429 responses if `max_concurrent_requests` or `requests_per_second` is set. Can be set via environment variable `POWERDNS_MAX_RETRIES`.",
+				Optional:            true,
+			},
+			"retry_max_wait": schema.StringAttribute{
+				MarkdownDescription: "Maximum duration (e.g. `\"10s\"`) to wait between two attempts of a request. The wait grows exponentially with every retry and honours `Retry-After` headers up to this limit. Defaults to `\"30s\"`. Can be set via environment variable `POWERDNS_RETRY_MAX_WAIT`.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the PowerDNS API at the same time. Unlimited if unset or `0`. If set, requests rejected with a 429 response are retried up to 3 times even if `max_retries` is `0`. Can be set via environment variable `POWERDNS_MAX_CONCURRENT_REQUESTS`.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of requests sent to the PowerDNS API per second. Requests exceeding the rate are delayed. Unlimited if unset or `0`. If set, requests rejected with a 429 response are retried up to 3 times even if `max_retries` is `0`. Can be set via environment variable `POWERDNS_REQUESTS_PER_SECOND`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -62,6 +142,38 @@ func (p *PowerdnsProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	for _, setting := range []struct {
+		name  string
+		value attr.Value
+		env   string
+	}{
+		{"server_id", data.ServerID, "POWERDNS_SERVER_ID"},
+		{"batch_window", data.BatchWindow, "POWERDNS_BATCH_WINDOW"},
+		{"max_retries", data.MaxRetries, "POWERDNS_MAX_RETRIES"},
+		{"retry_max_wait", data.RetryMaxWait, "POWERDNS_RETRY_MAX_WAIT"},
+		{"max_concurrent_requests", data.MaxConcurrentRequests, "POWERDNS_MAX_CONCURRENT_REQUESTS"},
+		{"requests_per_second", data.RequestsPerSecond, "POWERDNS_REQUESTS_PER_SECOND"},
+		{"ca_cert", data.CACert, "POWERDNS_CA_CERT"},
+		{"ca_file", data.CAFile, "POWERDNS_CA_FILE"},
+		{"client_cert", data.ClientCert, "POWERDNS_CLIENT_CERT"},
+		{"client_key", data.ClientKey, "POWERDNS_CLIENT_KEY"},
+		{"insecure_skip_verify", data.InsecureSkipVerify, "POWERDNS_INSECURE_SKIP_VERIFY"},
+		{"tls_server_name", data.TLSServerName, "POWERDNS_TLS_SERVER_NAME"},
+	} {
+		if setting.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.name),
+				"Unknown Provider Configuration Value",
+				fmt.Sprintf("The provider cannot create the PowerDNS API client as there is an unknown configuration value for %q. "+
+					"Either apply the source of the value first, set the value statically in the configuration, "+
+					"or use the %s environment variable.", setting.name, setting.env),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var apiKey string
 	if data.APIKey.IsUnknown() {
 		resp.Diagnostics.AddWarning("API Key is not set", "API Key is not set. This is required for authentication.")
@@ -98,7 +210,56 @@ func (p *PowerdnsProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	client, err := powerdns.New(ctx, apiKey, parsedServerURL.Host, parsedServerURL.Path, parsedServerURL.Scheme)
+	var opts []powerdns.Option
+
+	var batchWindow string
+	if data.BatchWindow.IsNull() {
+		batchWindow = os.Getenv("POWERDNS_BATCH_WINDOW")
+	} else {
+		batchWindow = data.BatchWindow.ValueString()
+	}
+	if batchWindow != "" {
+		window, err := time.ParseDuration(batchWindow)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Batch Window",
+				fmt.Sprintf("Invalid batch window: %v", err),
+			)
+			return
+		}
+		opts = append(opts, powerdns.WithBatchWindow(window))
+	}
+
+	maxRetries, retryMaxWait, ok := providerRetryOptions(data, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	opts = append(opts, powerdns.WithRetry(maxRetries, retryMaxWait))
+
+	maxConcurrent, rate, ok := providerLimitOptions(data, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	opts = append(opts, powerdns.WithMaxConcurrentRequests(maxConcurrent), powerdns.WithRequestsPerSecond(rate))
+	if maxRetries == 0 && (maxConcurrent > 0 || rate > 0) {
+		opts = append(opts, powerdns.WithTooManyRequestsRetry(tooManyRequestsRetries, retryMaxWait))
+	}
+
+	tlsOpts, ok := providerTLSOptions(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ok {
+		opts = append(opts, powerdns.WithTLS(tlsOpts))
+	}
+
+	serverID := stringOrEnv(data.ServerID, "POWERDNS_SERVER_ID")
+	if serverID == "" {
+		serverID = defaultServerID
+	}
+	opts = append(opts, powerdns.WithServerID(serverID))
+
+	client, err := powerdns.New(ctx, apiKey, parsedServerURL.Host, parsedServerURL.Path, parsedServerURL.Scheme, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
@@ -112,15 +273,26 @@ func (p *PowerdnsProvider) Configure(ctx context.Context, req provider.Configure
 
 func (p *PowerdnsProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAutoprimaryResource,
+		NewCAARecordsetResource,
+		NewMXRecordsetResource,
+		NewRecordResource,
 		NewRecordsetResource,
+		NewSRVRecordsetResource,
+		NewTSIGKeyResource,
+		NewTXTRecordsetResource,
 		NewZoneResource,
+		NewZoneCryptokeyResource,
+		NewZoneMetadataResource,
 	}
 }
 
 func (p *PowerdnsProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewAutoprimariesDataSource,
 		NewRecordsetDataSource,
 		NewZoneDataSource,
+		NewZoneMetadataDataSource,
 	}
 }
 
@@ -131,3 +303,130 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// stringOrEnv returns value, or the environment variable env if value is null.
+func stringOrEnv(value types.String, env string) string {
+	if value.IsNull() {
+		return os.Getenv(env)
+	}
+	return value.ValueString()
+}
+
+// providerRetryOptions reads the retry settings of the provider, applying
+// their defaults.
+func providerRetryOptions(data PowerdnsProviderModel, diags *diag.Diagnostics) (int, time.Duration, bool) {
+	maxRetries := defaultMaxRetries
+	if data.MaxRetries.IsNull() {
+		if env := os.Getenv("POWERDNS_MAX_RETRIES"); env != "" {
+			n, err := strconv.Atoi(env)
+			if err != nil || n < 0 {
+				diags.AddError("Invalid Max Retries", fmt.Sprintf("Invalid value of POWERDNS_MAX_RETRIES %q, expected a non-negative number.", env))
+				return 0, 0, false
+			}
+			maxRetries = n
+		}
+	} else {
+		if data.MaxRetries.ValueInt64() < 0 {
+			diags.AddError("Invalid Max Retries", "\"max_retries\" must not be negative.")
+			return 0, 0, false
+		}
+		maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	maxWait := powerdns.DefaultRetryMaxWait
+	if value := stringOrEnv(data.RetryMaxWait, "POWERDNS_RETRY_MAX_WAIT"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			diags.AddError("Invalid Retry Max Wait", fmt.Sprintf("Invalid retry max wait %q, expected a positive duration.", value))
+			return 0, 0, false
+		}
+		maxWait = d
+	}
+
+	return maxRetries, maxWait, true
+}
+
+// providerLimitOptions reads the settings limiting the number of concurrent
+// requests and the request rate. Zero means no limit.
+func providerLimitOptions(data PowerdnsProviderModel, diags *diag.Diagnostics) (int, float64, bool) {
+	var maxConcurrent int
+	if data.MaxConcurrentRequests.IsNull() {
+		if env := os.Getenv("POWERDNS_MAX_CONCURRENT_REQUESTS"); env != "" {
+			n, err := strconv.Atoi(env)
+			if err != nil || n < 0 {
+				diags.AddError("Invalid Max Concurrent Requests", fmt.Sprintf("Invalid value of POWERDNS_MAX_CONCURRENT_REQUESTS %q, expected a non-negative number.", env))
+				return 0, 0, false
+			}
+			maxConcurrent = n
+		}
+	} else {
+		if data.MaxConcurrentRequests.ValueInt64() < 0 {
+			diags.AddError("Invalid Max Concurrent Requests", "\"max_concurrent_requests\" must not be negative.")
+			return 0, 0, false
+		}
+		maxConcurrent = int(data.MaxConcurrentRequests.ValueInt64())
+	}
+
+	var rate float64
+	if data.RequestsPerSecond.IsNull() {
+		if env := os.Getenv("POWERDNS_REQUESTS_PER_SECOND"); env != "" {
+			r, err := strconv.ParseFloat(env, 64)
+			if err != nil || r < 0 {
+				diags.AddError("Invalid Requests Per Second", fmt.Sprintf("Invalid value of POWERDNS_REQUESTS_PER_SECOND %q, expected a non-negative number.", env))
+				return 0, 0, false
+			}
+			rate = r
+		}
+	} else {
+		if data.RequestsPerSecond.ValueFloat64() < 0 {
+			diags.AddError("Invalid Requests Per Second", "\"requests_per_second\" must not be negative.")
+			return 0, 0, false
+		}
+		rate = data.RequestsPerSecond.ValueFloat64()
+	}
+
+	return maxConcurrent, rate, true
+}
+
+// providerTLSOptions reads the TLS settings of the provider. It reports
+// whether any of them is set.
+func providerTLSOptions(data PowerdnsProviderModel, diags *diag.Diagnostics) (powerdns.TLSOptions, bool) {
+	var opts powerdns.TLSOptions
+
+	caCert := stringOrEnv(data.CACert, "POWERDNS_CA_CERT")
+	caFile := stringOrEnv(data.CAFile, "POWERDNS_CA_FILE")
+	if caCert != "" && caFile != "" {
+		diags.AddError("Conflicting CA Certificates", "Only one of \"ca_cert\" and \"ca_file\" can be set.")
+		return opts, false
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			diags.AddError("Invalid CA File", fmt.Sprintf("Unable to read CA file: %v", err))
+			return opts, false
+		}
+		caCert = string(pem)
+	}
+	opts.CACert = []byte(caCert)
+
+	opts.ClientCert = []byte(stringOrEnv(data.ClientCert, "POWERDNS_CLIENT_CERT"))
+	opts.ClientKey = []byte(stringOrEnv(data.ClientKey, "POWERDNS_CLIENT_KEY"))
+	opts.ServerName = stringOrEnv(data.TLSServerName, "POWERDNS_TLS_SERVER_NAME")
+
+	if data.InsecureSkipVerify.IsNull() {
+		if env := os.Getenv("POWERDNS_INSECURE_SKIP_VERIFY"); env != "" {
+			insecure, err := strconv.ParseBool(env)
+			if err != nil {
+				diags.AddError("Invalid Insecure Skip Verify", fmt.Sprintf("Invalid value of POWERDNS_INSECURE_SKIP_VERIFY: %v", err))
+				return opts, false
+			}
+			opts.InsecureSkipVerify = insecure
+		}
+	} else {
+		opts.InsecureSkipVerify = data.InsecureSkipVerify.ValueBool()
+	}
+
+	set := len(opts.CACert) > 0 || len(opts.ClientCert) > 0 || len(opts.ClientKey) > 0 ||
+		opts.InsecureSkipVerify || opts.ServerName != ""
+	return opts, set
+}
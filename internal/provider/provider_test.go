@@ -1,11 +1,22 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"sync/atomic"
 	"testing"
 
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -24,3 +35,170 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("POWERDNS_SERVER_URL must be set for acceptance tests")
 	}
 }
+
+// testAccClient returns a PowerDNS client configured from the acceptance test
+// environment, to modify server objects behind Terraform's back.
+func testAccClient(t *testing.T) *powerdns.Client {
+	serverURL, err := url.Parse(os.Getenv("POWERDNS_SERVER_URL"))
+	if err != nil {
+		t.Fatalf("invalid POWERDNS_SERVER_URL: %v", err)
+	}
+	client, err := powerdns.New(context.Background(), os.Getenv("POWERDNS_API_KEY"), serverURL.Host, serverURL.Path, serverURL.Scheme)
+	if err != nil {
+		t.Fatalf("unable to create PowerDNS client: %v", err)
+	}
+	return client
+}
+
+func TestProviderRetryOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		attr       types.Int64
+		env        string
+		maxRetries int
+		valid      bool
+	}{
+		{"default", types.Int64Null(), "", 0, true},
+		{"env", types.Int64Null(), "5", 5, true},
+		{"attribute", types.Int64Value(2), "", 2, true},
+		{"attribute overrides env", types.Int64Value(0), "5", 0, true},
+		{"invalid env", types.Int64Null(), "many", 0, false},
+		{"negative attribute", types.Int64Value(-1), "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("POWERDNS_MAX_RETRIES", tt.env)
+			t.Setenv("POWERDNS_RETRY_MAX_WAIT", "")
+
+			var diags diag.Diagnostics
+			data := PowerdnsProviderModel{MaxRetries: tt.attr, RetryMaxWait: types.StringNull()}
+			maxRetries, maxWait, ok := providerRetryOptions(data, &diags)
+			if ok != tt.valid || diags.HasError() == tt.valid {
+				t.Fatalf("expected valid %t, got %t with diagnostics %v", tt.valid, ok, diags)
+			}
+			if !tt.valid {
+				return
+			}
+			if maxRetries != tt.maxRetries {
+				t.Errorf("expected %d retries, got %d", tt.maxRetries, maxRetries)
+			}
+			if maxWait != powerdns.DefaultRetryMaxWait {
+				t.Errorf("expected default max wait %s, got %s", powerdns.DefaultRetryMaxWait, maxWait)
+			}
+		})
+	}
+}
+
+// configureTestProvider configures the provider with the given attribute
+// values, leaving all other attributes null.
+func configureTestProvider(t *testing.T, values map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	raw := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		raw[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, value := range values {
+		raw[name] = value
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, raw)},
+	}, resp)
+	return resp
+}
+
+func TestProviderConfigureUnknownValue(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":    tftypes.NewValue(tftypes.String, "secret"),
+		"server_url": tftypes.NewValue(tftypes.String, "http://localhost:8081/api/v1"),
+		"server_id":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for unknown server_id, got none")
+	}
+	if resp.ResourceData != nil {
+		t.Error("expected no client to be configured")
+	}
+	if got := resp.Diagnostics.Errors()[0].Summary(); got != "Unknown Provider Configuration Value" {
+		t.Errorf("unexpected error %q", got)
+	}
+}
+
+func TestProviderTooManyRequestsRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]tftypes.Value
+		status   int
+		requests int32
+		success  bool
+	}{
+		{"no limits", nil, http.StatusTooManyRequests, 1, false},
+		{"requests per second", map[string]tftypes.Value{
+			"requests_per_second": tftypes.NewValue(tftypes.Number, 1000),
+		}, http.StatusTooManyRequests, 2, true},
+		{"max concurrent requests", map[string]tftypes.Value{
+			"max_concurrent_requests": tftypes.NewValue(tftypes.Number, 4),
+		}, http.StatusTooManyRequests, 2, true},
+		{"server error with limits", map[string]tftypes.Value{
+			"requests_per_second": tftypes.NewValue(tftypes.Number, 1000),
+		}, http.StatusServiceUnavailable, 1, false},
+		{"retries disabled explicitly", map[string]tftypes.Value{
+			"requests_per_second": tftypes.NewValue(tftypes.Number, 1000),
+			"max_retries":         tftypes.NewValue(tftypes.Number, 0),
+		}, http.StatusTooManyRequests, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, env := range []string{"POWERDNS_MAX_RETRIES", "POWERDNS_MAX_CONCURRENT_REQUESTS", "POWERDNS_REQUESTS_PER_SECOND", "POWERDNS_BATCH_WINDOW"} {
+				t.Setenv(env, "")
+			}
+
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) == 1 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id": "example.net.", "name": "example.net.", "kind": "Native"}`))
+			}))
+			defer server.Close()
+
+			values := map[string]tftypes.Value{
+				"api_key":        tftypes.NewValue(tftypes.String, "secret"),
+				"server_url":     tftypes.NewValue(tftypes.String, server.URL+"/api/v1"),
+				"retry_max_wait": tftypes.NewValue(tftypes.String, "10ms"),
+			}
+			for name, value := range tt.values {
+				values[name] = value
+			}
+			resp := configureTestProvider(t, values)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			client := resp.ResourceData.(*powerdns.Client)
+			_, err := client.GetZone(context.Background(), "localhost", "example.net.")
+			if tt.success && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.success && err == nil {
+				t.Error("expected error, got none")
+			}
+			if requests != tt.requests {
+				t.Errorf("expected %d requests, got %d", tt.requests, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &RecordResource{}
+var _ resource.ResourceWithImportState = &RecordResource{}
+var _ resource.ResourceWithModifyPlan = &RecordResource{}
+var _ resource.ResourceWithValidateConfig = &RecordResource{}
+
+func NewRecordResource() resource.Resource {
+	return &RecordResource{}
+}
+
+// RecordResource manages a single record of a record set. Other records of
+// the same record set are left untouched, so several resources can share a
+// record set.
+type RecordResource struct {
+	client *powerdns.Client
+}
+
+type RecordResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	ZoneId   types.String `tfsdk:"zone_id"`
+	ServerId types.String `tfsdk:"server_id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Ttl      types.Int64  `tfsdk:"ttl"`
+	Content  types.String `tfsdk:"content"`
+	Disabled types.Bool   `tfsdk:"disabled"`
+
+	OverwriteExisting types.Bool `tfsdk:"overwrite_existing"`
+}
+
+func (r *RecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record"
+}
+
+func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "PowerDNS Record. Manages a single record of a record set, other records of the record set are kept. " +
+			"The record set is deleted when its last record is removed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "State ID for the record (only needed for internal technical purposes).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the zone this record belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"server_id": serverIDAttribute(),
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the record set this record belongs to (e.g. \"www.powerdns.com.\")",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of this record (e.g. \"A\", \"PTR\", \"MX\").",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "DNS TTL of the record, in seconds. The TTL applies to the whole record set, so all records of a record set must use the same TTL. " +
+					"Creating a record with a TTL that differs from the one of the existing records fails. " +
+					"Changing the TTL of a record changes it for all records of the record set, so the `ttl` of the other records has to be changed as well.",
+				Required: true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the record.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the record is disabled. Disabled records are not served. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"overwrite_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to take over a record with the same content which already exists when the resource is created. " +
+					"If `false`, creating the resource fails instead, and the existing record has to be imported. " +
+					"Note that destroying the resource deletes the record, even if it was created outside of this resource. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *RecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.client, req, resp)
+}
+
+func (r *RecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerdns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *powerdns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RecordResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateNameInZone(data.Name, data.ZoneId, &resp.Diagnostics)
+
+	if data.Type.IsNull() || data.Type.IsUnknown() || data.Content.IsNull() || data.Content.IsUnknown() {
+		return
+	}
+
+	if err := powerdns.ValidateContent(data.Type.ValueString(), data.Content.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Invalid Record Content",
+			fmt.Sprintf("Content is not valid for a record of type %s: %v", data.Type.ValueString(), err),
+		)
+	}
+}
+
+func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RecordResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.putRecord(ctx, &data, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RecordResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := data.ZoneId.ValueString()
+	serverId := data.ServerId.ValueString()
+	name := data.Name.ValueString()
+	rrType := data.Type.ValueString()
+	content := data.Content.ValueString()
+	tflog.Debug(ctx, "Reading record", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      name,
+		"type":      rrType,
+		"content":   content,
+	})
+	recordset, err := r.client.GetRecord(ctx, serverId, zoneId, name, rrType, content)
+	if powerdns.IsNotFound(err) {
+		tflog.Warn(ctx, "Record not found, removing it from state", map[string]interface{}{
+			"zone_id":   zoneId,
+			"server_id": serverId,
+			"name":      name,
+			"type":      rrType,
+			"content":   content,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get record '%s' of record set '%s' (type '%s'): %v", content, name, rrType, err))
+		return
+	}
+
+	recordObjectToResourceData(recordset, &data)
+	tflog.Debug(ctx, "Read record", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      name,
+		"type":      rrType,
+		"content":   content,
+		"ttl":       recordset.TTL,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RecordResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.putRecord(ctx, &data, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RecordResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := data.ZoneId.ValueString()
+	serverId := data.ServerId.ValueString()
+	name := data.Name.ValueString()
+	rrType := data.Type.ValueString()
+	content := data.Content.ValueString()
+	tflog.Debug(ctx, "Deleting record", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      name,
+		"type":      rrType,
+		"content":   content,
+	})
+	if err := r.client.RemoveRecord(ctx, serverId, zoneId, name, rrType, content); err != nil && !powerdns.IsNotFound(err) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete record '%s' of record set '%s' (type '%s'): %v", content, name, rrType, err))
+		return
+	}
+	tflog.Debug(ctx, "Deleted record", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      name,
+		"type":      rrType,
+		"content":   content,
+	})
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fields, err := parseImportID(req.ID, importServerID(r.client), "server_id", "zone_id", "recordset_name", "recordset_type", "content")
+	if err != nil {
+		resp.Diagnostics.AddError("Resource Import ID invalid", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), importZoneID(fields[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), importName(fields[2]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), strings.ToUpper(fields[3]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content"), fields[4])...)
+}
+
+// putRecord adds (if create is true) or updates the record described by data
+// and reads it back into data.
+func (r *RecordResource) putRecord(ctx context.Context, data *RecordResourceModel, create bool, diags *diag.Diagnostics) {
+	zoneId := data.ZoneId.ValueString()
+	serverId := data.ServerId.ValueString()
+	name := data.Name.ValueString()
+	rrType := data.Type.ValueString()
+	record := powerdns.Record{
+		Content:  data.Content.ValueString(),
+		Disabled: data.Disabled.ValueBool(),
+	}
+	tflog.Debug(ctx, "Writing record", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      name,
+		"type":      rrType,
+		"ttl":       data.Ttl.ValueInt64(),
+		"content":   record.Content,
+		"disabled":  record.Disabled,
+	})
+	var err error
+	if create {
+		err = r.client.AddRecord(ctx, serverId, zoneId, name, rrType, data.Ttl.ValueInt64(), record, data.OverwriteExisting.ValueBool())
+	} else {
+		err = r.client.PutRecord(ctx, serverId, zoneId, name, rrType, data.Ttl.ValueInt64(), record)
+	}
+	if errors.Is(err, powerdns.ErrRecordExists) {
+		diags.AddError(
+			"Record Already Exists",
+			fmt.Sprintf("Record '%s' of record set '%s' (type '%s') already exists in zone '%s'. "+
+				"Import it with `terraform import` using the ID '%s' to manage it, "+
+				"or set \"overwrite_existing\" to take it over.", record.Content, name, rrType, zoneId,
+				formatImportID(serverId, zoneId, name, rrType, record.Content)),
+		)
+		return
+	}
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Unable to write record '%s' of record set '%s' (type '%s'): %v", record.Content, name, rrType, err))
+		return
+	}
+
+	recordset, err := r.client.GetRecord(ctx, serverId, zoneId, name, rrType, record.Content)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Unable to get record '%s' of record set '%s' (type '%s'): %v", record.Content, name, rrType, err))
+		return
+	}
+
+	recordObjectToResourceData(recordset, data)
+	tflog.Debug(ctx, "Wrote record", map[string]interface{}{
+		"id":        data.Id.ValueString(),
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      name,
+		"type":      rrType,
+	})
+}
+
+// recordObjectToResourceData stores the single record of recordset in data.
+// The name, type and content are kept as configured, the server may spell
+// them differently.
+func recordObjectToResourceData(recordset *powerdns.RecordSet, data *RecordResourceModel) {
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", data.ZoneId.ValueString(), data.Name.ValueString(), data.Type.ValueString(), data.Content.ValueString()))
+	data.Ttl = types.Int64Value(recordset.TTL)
+	data.Disabled = types.BoolValue(recordset.Records[0].Disabled)
+	if data.OverwriteExisting.IsNull() || data.OverwriteExisting.IsUnknown() {
+		data.OverwriteExisting = types.BoolValue(false)
+	}
+}
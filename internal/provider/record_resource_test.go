@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsRecordResource(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing with two records sharing a record set
+			{
+				Config: testAccPowerdnsRecordResourceConfig(recordsetName, "first", "192.168.0.1", false) +
+					testAccPowerdnsRecordResourceConfig(recordsetName, "second", "192.168.0.2", false) +
+					testAccPowerdnsRecordResourceRecordsetConfig(recordsetName, "powerdns_record.first", "powerdns_record.second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_record.first", "content", "192.168.0.1"),
+					resource.TestCheckResourceAttr("powerdns_record.first", "disabled", "false"),
+					resource.TestCheckResourceAttr("powerdns_record.second", "content", "192.168.0.2"),
+					resource.TestCheckResourceAttr("data.powerdns_recordset.test", "records.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerdns_record.first",
+				ImportStateId:     fmt.Sprintf("localhost/example.net./%s/A/192.168.0.1", recordsetName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPowerdnsRecordResourceConfig(recordsetName, "first", "192.168.0.1", true) +
+					testAccPowerdnsRecordResourceConfig(recordsetName, "second", "192.168.0.2", false) +
+					testAccPowerdnsRecordResourceRecordsetConfig(recordsetName, "powerdns_record.first", "powerdns_record.second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_record.first", "disabled", "true"),
+					resource.TestCheckResourceAttr("data.powerdns_recordset.test", "records.#", "2"),
+				),
+			},
+			// An existing record is not taken over
+			{
+				Config: testAccPowerdnsRecordResourceConfig(recordsetName, "first", "192.168.0.1", true) +
+					testAccPowerdnsRecordResourceConfig(recordsetName, "second", "192.168.0.2", false) +
+					testAccPowerdnsRecordResourceConfig(recordsetName, "duplicate", "192.168.0.2", false),
+				ExpectError: regexp.MustCompile("Record Already Exists"),
+			},
+			// A record with another TTL than the record set is rejected
+			{
+				Config: testAccPowerdnsRecordResourceConfig(recordsetName, "first", "192.168.0.1", true) +
+					testAccPowerdnsRecordResourceConfig(recordsetName, "second", "192.168.0.2", false) +
+					strings.Replace(testAccPowerdnsRecordResourceConfig(recordsetName, "third", "192.168.0.3", false), "ttl = 60", "ttl = 120", 1),
+				ExpectError: regexp.MustCompile(`must use the\s+same TTL`),
+			},
+			// Removing one record keeps the other one
+			{
+				Config: testAccPowerdnsRecordResourceConfig(recordsetName, "second", "192.168.0.2", false) +
+					testAccPowerdnsRecordResourceRecordsetConfig(recordsetName, "powerdns_record.second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_recordset.test", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.powerdns_recordset.test", "records.0", "192.168.0.2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsRecordResourceConfig(name, resourceName, content string, disabled bool) string {
+	return fmt.Sprintf(`
+resource "powerdns_record" %[2]q {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  type = "A"
+  ttl = 60
+  content = %[3]q
+  disabled = %[4]t
+}
+`, name, resourceName, content, disabled)
+}
+
+func testAccPowerdnsRecordResourceRecordsetConfig(name string, dependsOn ...string) string {
+	return fmt.Sprintf(`
+data "powerdns_recordset" "test" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  type = "A"
+  depends_on = [%[2]s]
+}
+`, name, strings.Join(dependsOn, ", "))
+}
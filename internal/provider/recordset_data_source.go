@@ -33,6 +33,8 @@ type RecordsetDataSourceModel struct {
 	Type     types.String `tfsdk:"type"`
 	Ttl      types.Int64  `tfsdk:"ttl"`
 	Records  types.List   `tfsdk:"records"`
+	Record   types.List   `tfsdk:"record"`
+	Comments types.List   `tfsdk:"comments"`
 }
 
 func (d *RecordsetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,10 +54,7 @@ func (d RecordsetDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				MarkdownDescription: "ID of the zone this record set belongs to.",
 				Required:            true,
 			},
-			"server_id": schema.StringAttribute{
-				MarkdownDescription: "The id of the server.",
-				Required:            true,
-			},
+			"server_id": serverIDDataSourceAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name for record set (e.g. \"www.powerdns.com.\")",
 				Required:            true,
@@ -73,6 +72,38 @@ func (d RecordsetDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				ElementType:         types.StringType,
 				Computed:            true,
 			},
+			"record": schema.ListNestedAttribute{
+				MarkdownDescription: "All records in this record set, including their disabled flag.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							MarkdownDescription: "Content of the record.",
+							Computed:            true,
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the record is disabled.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"comments": schema.ListNestedAttribute{
+				MarkdownDescription: "Comments of the record set.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							MarkdownDescription: "The comment text.",
+							Computed:            true,
+						},
+						"account": schema.StringAttribute{
+							MarkdownDescription: "Name of the account that added the comment.",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -107,6 +138,7 @@ func (d RecordsetDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
+	data.ServerId = serverIDOrDefault(d.client, data.ServerId)
 	zoneId := data.ZoneId.ValueString()
 	serverId := data.ServerId.ValueString()
 	recordSetName := data.Name.ValueString()
@@ -125,7 +157,7 @@ func (d RecordsetDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	records := make([]attr.Value, len(recordset.Records))
 	for i, record := range recordset.Records {
-		records[i] = types.StringValue(record)
+		records[i] = types.StringValue(record.Content)
 	}
 
 	var diags diag.Diagnostics
@@ -134,6 +166,12 @@ func (d RecordsetDataSource) Read(ctx context.Context, req datasource.ReadReques
 	data.Type = types.StringValue(recordset.Type)
 	data.Ttl = types.Int64Value(recordset.TTL)
 	data.Records, diags = types.ListValue(types.StringType, records)
+	recordList, recordDiags := recordsetRecordsValue(recordset.Records)
+	diags.Append(recordDiags...)
+	data.Record = recordList
+	comments, commentDiags := recordsetCommentsValue(recordset.Comments)
+	diags.Append(commentDiags...)
+	data.Comments = comments
 
 	tflog.Debug(ctx, "Read record set", map[string]interface{}{
 		"zone_id":   zoneId,
@@ -11,6 +11,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -18,6 +24,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &RecordsetResource{}
 var _ resource.ResourceWithImportState = &RecordsetResource{}
+var _ resource.ResourceWithModifyPlan = &RecordsetResource{}
+var _ resource.ResourceWithValidateConfig = &RecordsetResource{}
+var _ resource.ResourceWithUpgradeState = &RecordsetResource{}
 
 func NewRecordsetResource() resource.Resource {
 	return &RecordsetResource{}
@@ -28,13 +37,39 @@ type RecordsetResource struct {
 }
 
 type RecordsetResourceModel struct {
-	Id       types.String `tfsdk:"id"`
-	ZoneId   types.String `tfsdk:"zone_id"`
-	ServerId types.String `tfsdk:"server_id"`
-	Name     types.String `tfsdk:"name"`
-	Type     types.String `tfsdk:"type"`
-	Ttl      types.Int64  `tfsdk:"ttl"`
-	Records  types.List   `tfsdk:"records"`
+	Id                types.String `tfsdk:"id"`
+	ZoneId            types.String `tfsdk:"zone_id"`
+	ServerId          types.String `tfsdk:"server_id"`
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	Ttl               types.Int64  `tfsdk:"ttl"`
+	Records           types.Set    `tfsdk:"records"`
+	Record            types.Set    `tfsdk:"record"`
+	Comments          types.List   `tfsdk:"comments"`
+	QuoteTXT          types.Bool   `tfsdk:"quote_txt"`
+	OverwriteExisting types.Bool   `tfsdk:"overwrite_existing"`
+}
+
+// RecordsetRecordModel describes a single record of a record set.
+type RecordsetRecordModel struct {
+	Content  types.String `tfsdk:"content"`
+	Disabled types.Bool   `tfsdk:"disabled"`
+}
+
+var recordsetRecordAttrTypes = map[string]attr.Type{
+	"content":  types.StringType,
+	"disabled": types.BoolType,
+}
+
+// RecordsetCommentModel describes a comment of a record set.
+type RecordsetCommentModel struct {
+	Content types.String `tfsdk:"content"`
+	Account types.String `tfsdk:"account"`
+}
+
+var recordsetCommentAttrTypes = map[string]attr.Type{
+	"content": types.StringType,
+	"account": types.StringType,
 }
 
 func (r *RecordsetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,6 +79,8 @@ func (r *RecordsetResource) Metadata(ctx context.Context, req resource.MetadataR
 func (t RecordsetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "PowerDNS Zone",
+		// Version 1 turned records and record from lists into sets.
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -53,32 +90,144 @@ func (t RecordsetResource) Schema(ctx context.Context, req resource.SchemaReques
 			"zone_id": schema.StringAttribute{
 				MarkdownDescription: "ID of the zone this record set belongs to.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"server_id": schema.StringAttribute{
-				MarkdownDescription: "The id of the server.",
-				Required:            true,
-			},
+			"server_id": serverIDAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name for record set (e.g. \"www.powerdns.com.\")",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					fqdn(),
+				},
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "Type of this record (e.g. \"A\", \"PTR\", \"MX\").",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"ttl": schema.Int64Attribute{
 				MarkdownDescription: "DNS TTL of the records, in seconds.",
 				Required:            true,
 			},
-			"records": schema.ListAttribute{
-				MarkdownDescription: "All records in this record set.",
+			"records": schema.SetAttribute{
+				MarkdownDescription: "All records in this record set. Conflicts with `record`.",
 				ElementType:         types.StringType,
-				Required:            true,
+				Optional:            true,
+				// Computed only to let the plan keep the server's spelling of
+				// records that are semantically equal to the configured ones.
+				Computed: true,
+				PlanModifiers: []planmodifier.Set{
+					semanticallyEqualRecords(),
+				},
+				Validators: []validator.Set{
+					recordContent(),
+				},
+			},
+			"overwrite_existing": overwriteExistingAttribute(),
+			"quote_txt": schema.BoolAttribute{
+				MarkdownDescription: "Whether records are plain text values, which are quoted, escaped and split into strings of at most 255 bytes before they are sent to the server. Only valid for `TXT` and `SPF` record sets. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"comments": schema.ListNestedAttribute{
+				MarkdownDescription: "Comments of the record set. Existing comments are kept if unset, an empty list removes all comments.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							MarkdownDescription: "The comment text.",
+							Required:            true,
+						},
+						"account": schema.StringAttribute{
+							MarkdownDescription: "Name of the account that added the comment.",
+							Optional:            true,
+						},
+					},
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"record": schema.SetNestedBlock{
+				MarkdownDescription: "A record of this record set. Use instead of `records` to disable individual records.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							MarkdownDescription: "Content of the record.",
+							Required:            true,
+							Validators: []validator.String{
+								recordContent(),
+							},
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the record is disabled. Disabled records are not served. Defaults to `false`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r RecordsetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RecordsetResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Records.IsUnknown() || data.Record.IsUnknown() {
+		return
+	}
+
+	validateNameInZone(data.Name, data.ZoneId, &resp.Diagnostics)
+
+	hasRecords := !data.Records.IsNull()
+	hasRecord := len(data.Record.Elements()) > 0
+	if hasRecords && hasRecord {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("record"),
+			"Conflicting Attributes",
+			"Only one of \"records\" and \"record\" can be set.",
+		)
+	}
+	if !hasRecords && !hasRecord {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("records"),
+			"Missing Attribute",
+			"One of \"records\" or \"record\" must be set.",
+		)
+	}
+
+	if data.QuoteTXT.ValueBool() && !data.Type.IsUnknown() && !isTXTType(data.Type.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("quote_txt"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("\"quote_txt\" can only be set for TXT and SPF record sets, not for type %s.", data.Type.ValueString()),
+		)
 	}
 }
 
+func (r *RecordsetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.client, req, resp)
+}
+
 func (r *RecordsetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -105,6 +254,10 @@ func (r RecordsetResource) Create(ctx context.Context, req resource.CreateReques
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	// The server id may be inherited from the provider, which only the plan
+	// knows.
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("server_id"), &data.ServerId)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -122,6 +275,15 @@ func (r RecordsetResource) Create(ctx context.Context, req resource.CreateReques
 	recordSetName := data.Name.ValueString()
 	recordSetType := data.Type.ValueString()
 	recordSetTtl := data.Ttl.ValueInt64()
+
+	if !data.OverwriteExisting.ValueBool() {
+		importID := formatImportID(serverId, zoneId, recordSetName, recordSetType)
+		checkRecordSetAbsent(ctx, r.client, serverId, zoneId, recordSetName, recordSetType, importID, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	tflog.Debug(ctx, "Creating record set", map[string]interface{}{
 		"zone_id":   zoneId,
 		"server_id": serverId,
@@ -177,6 +339,16 @@ func (r RecordsetResource) Read(ctx context.Context, req resource.ReadRequest, r
 		"type":      recordSetType,
 	})
 	recordset, err := r.client.GetRecordSet(ctx, serverId, zoneId, recordSetName, recordSetType)
+	if powerdns.IsNotFound(err) {
+		tflog.Warn(ctx, "Record set not found, removing it from state", map[string]interface{}{
+			"zone_id":   zoneId,
+			"server_id": serverId,
+			"name":      recordSetName,
+			"type":      recordSetType,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get record set '%s' (type '%s'): %v", recordSetName, recordSetType, err))
 		return
@@ -207,9 +379,17 @@ func (r RecordsetResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	recordset := &powerdns.RecordSet{}
-	RecordsetResourceModelToObject(ctx, data, recordset)
+	diags = RecordsetResourceModelToObject(ctx, data, recordset)
 	resp.Diagnostics.Append(diags...)
 
+	// The plan holds the prior comments if none are configured. Leave them
+	// out of the update, so comments added outside of Terraform are kept.
+	var configComments types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("comments"), &configComments)...)
+	if configComments.IsNull() {
+		recordset.Comments = nil
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -275,7 +455,7 @@ func (r RecordsetResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 
 	recordset := &powerdns.RecordSet{}
-	RecordsetResourceModelToObject(ctx, data, recordset)
+	diags = RecordsetResourceModelToObject(ctx, data, recordset)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -290,7 +470,7 @@ func (r RecordsetResource) Delete(ctx context.Context, req resource.DeleteReques
 		"name":      recordset.Name,
 		"type":      recordset.Type,
 	})
-	if err := r.client.DeleteRecordSet(ctx, serverId, zoneId, recordset); err != nil {
+	if err := r.client.DeleteRecordSet(ctx, serverId, zoneId, recordset); err != nil && !powerdns.IsNotFound(err) {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete record set '%s': %v", recordset.Name, err))
 		return
 	}
@@ -305,53 +485,495 @@ func (r RecordsetResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r RecordsetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	splittedID := strings.Split(req.ID, "/")
-
-	if len(splittedID) != 4 {
-		resp.Diagnostics.AddError(
-			"Resource Import ID invalid",
-			fmt.Sprintf("ID '%s' should be in format 'server_id/zone_id/recordset_name/recordset_type'", req.ID),
-		)
+	fields, err := parseImportID(req.ID, importServerID(r.client), "server_id", "zone_id", "recordset_name", "recordset_type")
+	if err != nil {
+		resp.Diagnostics.AddError("Resource Import ID invalid", err.Error())
 		return
 	}
-	serverID := splittedID[0]
-	zoneID := splittedID[1]
-	recordsetName := splittedID[2]
-	recordsetType := splittedID[3]
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), serverID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), zoneID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), recordsetName)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), recordsetType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), importZoneID(fields[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), importName(fields[2]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), strings.ToUpper(fields[3]))...)
 }
 
 func RecordsetResourceModelToObject(ctx context.Context, data RecordsetResourceModel, recordset *powerdns.RecordSet) diag.Diagnostics {
-	var records []string
-	diags := data.Records.ElementsAs(ctx, &records, false)
-	if diags.HasError() {
-		return diags
-	}
+	var diags diag.Diagnostics
 
 	recordset.Name = data.Name.ValueString()
 	recordset.Type = data.Type.ValueString()
 	recordset.TTL = data.Ttl.ValueInt64()
-	recordset.Records = records
+	recordset.Records = nil
+	recordset.Comments = nil
+
+	if !data.Records.IsNull() {
+		var records []string
+		diags.Append(data.Records.ElementsAs(ctx, &records, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		for _, record := range records {
+			recordset.Records = append(recordset.Records, powerdns.Record{Content: quoteContent(data.QuoteTXT, record)})
+		}
+	} else {
+		var records []RecordsetRecordModel
+		diags.Append(data.Record.ElementsAs(ctx, &records, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		for _, record := range records {
+			recordset.Records = append(recordset.Records, powerdns.Record{
+				Content:  quoteContent(data.QuoteTXT, record.Content.ValueString()),
+				Disabled: record.Disabled.ValueBool(),
+			})
+		}
+	}
+
+	if !data.Comments.IsNull() && !data.Comments.IsUnknown() {
+		var comments []RecordsetCommentModel
+		diags.Append(data.Comments.ElementsAs(ctx, &comments, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		recordset.Comments = make([]powerdns.Comment, len(comments))
+		for i, comment := range comments {
+			recordset.Comments[i] = powerdns.Comment{
+				Content: comment.Content.ValueString(),
+				Account: comment.Account.ValueString(),
+			}
+		}
+	}
 
 	return diags
 }
 
+// recordsetObjectToResourceData stores recordset in data. The records are
+// stored in the same form as before, as record blocks if data has any and in
+// the records list otherwise.
 func recordsetObjectToResourceData(ctx context.Context, recordset *powerdns.RecordSet, data *RecordsetResourceModel) diag.Diagnostics {
-	records := make([]attr.Value, len(recordset.Records))
-	for i, record := range recordset.Records {
-		records[i] = types.StringValue(record)
-	}
-
 	var diags diag.Diagnostics
 	data.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", data.ZoneId.ValueString(), data.Name.ValueString(), data.Type.ValueString()))
 	data.Name = types.StringValue(recordset.Name)
 	data.Type = types.StringValue(recordset.Type)
 	data.Ttl = types.Int64Value(recordset.TTL)
-	data.Records, diags = types.ListValue(types.StringType, records)
+	if data.QuoteTXT.IsNull() || data.QuoteTXT.IsUnknown() {
+		data.QuoteTXT = types.BoolValue(false)
+	}
+	if data.OverwriteExisting.IsNull() || data.OverwriteExisting.IsUnknown() {
+		data.OverwriteExisting = types.BoolValue(false)
+	}
+
+	if data.QuoteTXT.ValueBool() {
+		// Quoted records are stored as the plain values they were made from.
+		// Content which cannot be unquoted is kept as is, to show up in the
+		// plan.
+		for i := range recordset.Records {
+			if value, err := powerdns.UnquoteTXT(recordset.Records[i].Content); err == nil {
+				recordset.Records[i].Content = value
+			}
+		}
+	} else {
+		// Records that only differ in spelling from the known ones, e.g. by
+		// case or a missing trailing dot, keep their known spelling.
+		var known []string
+		if len(data.Record.Elements()) > 0 {
+			var records []RecordsetRecordModel
+			diags.Append(data.Record.ElementsAs(ctx, &records, false)...)
+			for _, record := range records {
+				known = append(known, record.Content.ValueString())
+			}
+		} else if !data.Records.IsNull() && !data.Records.IsUnknown() {
+			diags.Append(data.Records.ElementsAs(ctx, &known, false)...)
+		}
+		for i := range recordset.Records {
+			recordset.Records[i].Content = knownSpelling(recordset.Type, recordset.Records[i].Content, known)
+		}
+	}
+
+	recordObjType := types.ObjectType{AttrTypes: recordsetRecordAttrTypes}
+	if len(data.Record.Elements()) > 0 {
+		records, d := recordsetRecordsValue(recordset.Records)
+		diags.Append(d...)
+		data.Records = types.SetNull(types.StringType)
+		data.Record, d = types.SetValue(recordObjType, records.Elements())
+		diags.Append(d...)
+	} else {
+		records := make([]attr.Value, len(recordset.Records))
+		for i, record := range recordset.Records {
+			records[i] = types.StringValue(record.Content)
+		}
+		var d diag.Diagnostics
+		data.Records, d = types.SetValue(types.StringType, records)
+		diags.Append(d...)
+		data.Record = types.SetValueMust(recordObjType, []attr.Value{})
+	}
+
+	comments, d := recordsetCommentsValue(recordset.Comments)
+	diags.Append(d...)
+	data.Comments = comments
 
 	return diags
 }
+
+// recordContent returns a validator which checks that record content is
+// well-formed for the type of the record set.
+func recordContent() recordContentValidator {
+	return recordContentValidator{}
+}
+
+type recordContentValidator struct{}
+
+func (v recordContentValidator) Description(ctx context.Context) string {
+	return "record content must be valid for the record type"
+}
+
+func (v recordContentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v recordContentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	v.validate(ctx, req.Config, req.Path, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v recordContentValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, elem := range req.ConfigValue.Elements() {
+		content, ok := elem.(types.String)
+		if !ok {
+			continue
+		}
+		v.validate(ctx, req.Config, req.Path.AtSetValue(elem), content, &resp.Diagnostics)
+	}
+}
+
+func (v recordContentValidator) validate(ctx context.Context, config tfsdk.Config, p path.Path, content types.String, diags *diag.Diagnostics) {
+	if content.IsNull() || content.IsUnknown() {
+		return
+	}
+
+	var rrType types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("type"), &rrType)...)
+	if rrType.IsNull() || rrType.IsUnknown() {
+		return
+	}
+
+	var quote types.Bool
+	diags.Append(config.GetAttribute(ctx, path.Root("quote_txt"), &quote)...)
+	if quote.IsUnknown() {
+		return
+	}
+
+	if err := powerdns.ValidateContent(rrType.ValueString(), quoteContent(quote, content.ValueString())); err != nil {
+		diags.AddAttributeError(
+			p,
+			"Invalid Record Content",
+			fmt.Sprintf("Content is not valid for a record of type %s: %v", rrType.ValueString(), err),
+		)
+	}
+}
+
+// validateNameInZone adds an error to diags if the record set name is not
+// within the zone. Zone ids containing escaped characters, which differ from
+// the zone name, are not checked.
+func validateNameInZone(name, zoneId types.String, diags *diag.Diagnostics) {
+	if name.IsNull() || name.IsUnknown() || zoneId.IsNull() || zoneId.IsUnknown() {
+		return
+	}
+	if strings.Contains(zoneId.ValueString(), "=") {
+		return
+	}
+
+	if !powerdns.IsInZone(name.ValueString(), zoneId.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Name Outside of Zone",
+			fmt.Sprintf("Name '%s' is not within zone '%s'.", name.ValueString(), zoneId.ValueString()),
+		)
+	}
+}
+
+// overwriteExistingAttribute returns the schema of the overwrite_existing
+// attribute, which is shared by all record set resources.
+func overwriteExistingAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Whether to replace the records of a record set which already exists when the resource is created. " +
+			"If `false`, creating the resource fails instead, and the existing record set has to be imported. Defaults to `false`.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// checkRecordSetAbsent adds an error to diags if the record set already
+// exists, so that it is not taken over unnoticed by a new resource.
+func checkRecordSetAbsent(ctx context.Context, client *powerdns.Client, serverId, zoneId, name, rrType, importID string, diags *diag.Diagnostics) {
+	_, err := client.GetRecordSet(ctx, serverId, zoneId, name, rrType)
+	if powerdns.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Unable to check whether record set '%s' (type '%s') exists: %v", name, rrType, err))
+		return
+	}
+
+	diags.AddError(
+		"Record Set Already Exists",
+		fmt.Sprintf("Record set '%s' (type '%s') already exists in zone '%s'. "+
+			"Import it with `terraform import` using the ID '%s' to manage it, "+
+			"or set \"overwrite_existing\" to replace its records.", name, rrType, zoneId, importID),
+	)
+}
+
+// quoteContent turns the plain text value content into TXT record content if
+// quote is true, and returns content unchanged otherwise.
+func quoteContent(quote types.Bool, content string) string {
+	if quote.ValueBool() {
+		return powerdns.QuoteTXT(content)
+	}
+	return content
+}
+
+// isTXTType reports whether records of type rrType hold character strings.
+func isTXTType(rrType string) bool {
+	switch strings.ToUpper(rrType) {
+	case "TXT", "SPF":
+		return true
+	}
+	return false
+}
+
+// knownSpelling returns the element of known which is semantically equal to
+// content, or content itself if there is none.
+func knownSpelling(rrType, content string, known []string) string {
+	for _, k := range known {
+		if powerdns.SameContent(rrType, k, content) {
+			return k
+		}
+	}
+	return content
+}
+
+// semanticallyEqualRecords returns a plan modifier which keeps the records
+// of the prior state if the configured records only differ in spelling.
+func semanticallyEqualRecords() planmodifier.Set {
+	return semanticallyEqualRecordsModifier{}
+}
+
+type semanticallyEqualRecordsModifier struct{}
+
+func (m semanticallyEqualRecordsModifier) Description(ctx context.Context) string {
+	return "Keeps the prior records if the configured ones are semantically equal."
+}
+
+func (m semanticallyEqualRecordsModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m semanticallyEqualRecordsModifier) PlanModifySet(ctx context.Context, req planmodifier.SetRequest, resp *planmodifier.SetResponse) {
+	// Records are only computed to allow keeping the prior spelling, without
+	// configuration there is nothing to compute.
+	if req.ConfigValue.IsNull() {
+		resp.PlanValue = types.SetNull(types.StringType)
+		return
+	}
+	if req.ConfigValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+
+	var rrType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &rrType)...)
+	if resp.Diagnostics.HasError() || rrType.IsUnknown() {
+		return
+	}
+
+	// Plain text values are compared as they are, their quoted spelling is
+	// never stored.
+	var quote types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("quote_txt"), &quote)...)
+	if resp.Diagnostics.HasError() || quote.IsUnknown() || quote.ValueBool() {
+		return
+	}
+
+	var configured, prior []string
+	resp.Diagnostics.Append(req.ConfigValue.ElementsAs(ctx, &configured, false)...)
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if sameRecords(rrType.ValueString(), configured, prior) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// sameRecords reports whether a and b contain the same records, regardless
+// of order and spelling.
+func sameRecords(rrType string, a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, content := range a {
+		counts[powerdns.CanonicalContent(rrType, content)]++
+	}
+	for _, content := range b {
+		canonical := powerdns.CanonicalContent(rrType, content)
+		if counts[canonical] == 0 {
+			return false
+		}
+		counts[canonical]--
+	}
+	return true
+}
+
+// recordsetRecordsValue converts records to the value of a record attribute.
+func recordsetRecordsValue(records []powerdns.Record) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	objType := types.ObjectType{AttrTypes: recordsetRecordAttrTypes}
+	elems := make([]attr.Value, len(records))
+	for i, record := range records {
+		var d diag.Diagnostics
+		elems[i], d = types.ObjectValue(recordsetRecordAttrTypes, map[string]attr.Value{
+			"content":  types.StringValue(record.Content),
+			"disabled": types.BoolValue(record.Disabled),
+		})
+		diags.Append(d...)
+	}
+
+	list, d := types.ListValue(objType, elems)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+// recordsetCommentsValue converts comments to the value of a comments
+// attribute. Comments without account get a null account.
+func recordsetCommentsValue(comments []powerdns.Comment) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	objType := types.ObjectType{AttrTypes: recordsetCommentAttrTypes}
+	elems := make([]attr.Value, len(comments))
+	for i, comment := range comments {
+		account := types.StringNull()
+		if comment.Account != "" {
+			account = types.StringValue(comment.Account)
+		}
+
+		var d diag.Diagnostics
+		elems[i], d = types.ObjectValue(recordsetCommentAttrTypes, map[string]attr.Value{
+			"content": types.StringValue(comment.Content),
+			"account": account,
+		})
+		diags.Append(d...)
+	}
+
+	list, d := types.ListValue(objType, elems)
+	diags.Append(d...)
+
+	return list, diags
+}
+
+func (r RecordsetResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := recordsetResourceSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior RecordsetResourceModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := RecordsetResourceModel{
+					Id:                prior.Id,
+					ZoneId:            prior.ZoneId,
+					ServerId:          prior.ServerId,
+					Name:              prior.Name,
+					Type:              prior.Type,
+					Ttl:               prior.Ttl,
+					Records:           types.SetNull(types.StringType),
+					Record:            types.SetValueMust(types.ObjectType{AttrTypes: recordsetRecordAttrTypes}, []attr.Value{}),
+					Comments:          types.ListNull(types.ObjectType{AttrTypes: recordsetCommentAttrTypes}),
+					QuoteTXT:          types.BoolValue(false),
+					OverwriteExisting: types.BoolValue(false),
+				}
+
+				if !prior.Records.IsNull() {
+					var diags diag.Diagnostics
+					data.Records, diags = types.SetValue(types.StringType, prior.Records.Elements())
+					resp.Diagnostics.Append(diags...)
+				}
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// RecordsetResourceModelV0 describes the state of schema version 0, which
+// stored records in a list.
+type RecordsetResourceModelV0 struct {
+	Id       types.String `tfsdk:"id"`
+	ZoneId   types.String `tfsdk:"zone_id"`
+	ServerId types.String `tfsdk:"server_id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Ttl      types.Int64  `tfsdk:"ttl"`
+	Records  types.List   `tfsdk:"records"`
+}
+
+// recordsetResourceSchemaV0 returns schema version 0, as released before
+// record sets gained comments, record blocks and unordered records.
+func recordsetResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "PowerDNS Zone",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "State ID for the record set (only needed for internal technical purposes).",
+				Computed:            true,
+			},
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the zone this record set belongs to.",
+				Required:            true,
+			},
+			"server_id": schema.StringAttribute{
+				MarkdownDescription: "The id of the server.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name for record set (e.g. \"www.powerdns.com.\")",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of this record (e.g. \"A\", \"PTR\", \"MX\").",
+				Required:            true,
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "DNS TTL of the records, in seconds.",
+				Required:            true,
+			},
+			"records": schema.ListAttribute{
+				MarkdownDescription: "All records in this record set.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+		},
+	}
+}
@@ -1,21 +1,31 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccPowerdnsRecordsetResource(t *testing.T) {
 	recordsetName := randomRecordsetName(5, "example.net.")
+	renamedRecordsetName := randomRecordsetName(5, "example.net.")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", "www.example.org.", "A", 500, []string{"192.168.0.3"}),
+				ExpectError: regexp.MustCompile(`is not within zone`),
+			},
 			// Create and Read testing
 			{
 				Config: testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", recordsetName, "A", 500, []string{"192.168.0.3"}),
@@ -25,7 +35,8 @@ func TestAccPowerdnsRecordsetResource(t *testing.T) {
 					resource.TestCheckResourceAttr("powerdns_recordset.test", "name", recordsetName),
 					resource.TestCheckResourceAttr("powerdns_recordset.test", "type", "A"),
 					resource.TestCheckResourceAttr("powerdns_recordset.test", "ttl", "500"),
-					// resource.TestCheckResourceAttr("powerdns_recordset.test", "records", "[\"192.168.0.3\"]"),
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "records.#", "1"),
+					resource.TestCheckTypeSetElemAttr("powerdns_recordset.test", "records.*", "192.168.0.3"),
 				),
 			},
 			// ImportState testing
@@ -35,6 +46,13 @@ func TestAccPowerdnsRecordsetResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState testing without server_id and trailing dots
+			{
+				ResourceName:      "powerdns_recordset.test",
+				ImportStateId:     fmt.Sprintf("example.net/%s/a", strings.TrimSuffix(recordsetName, ".")),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", recordsetName, "A", 800, []string{"192.168.0.2", "192.168.0.4"}),
@@ -44,7 +62,93 @@ func TestAccPowerdnsRecordsetResource(t *testing.T) {
 					resource.TestCheckResourceAttr("powerdns_recordset.test", "name", recordsetName),
 					resource.TestCheckResourceAttr("powerdns_recordset.test", "type", "A"),
 					resource.TestCheckResourceAttr("powerdns_recordset.test", "ttl", "800"),
-					// resource.TestCheckResourceAttr("powerdns_recordset.test", "records", "[\"192.168.0.2\", \"192.168.0.4\"]"),
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "records.#", "2"),
+					resource.TestCheckTypeSetElemAttr("powerdns_recordset.test", "records.*", "192.168.0.2"),
+					resource.TestCheckTypeSetElemAttr("powerdns_recordset.test", "records.*", "192.168.0.4"),
+				),
+			},
+			// Records in a different order than returned by the server must
+			// not cause a diff
+			{
+				Config:   testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", recordsetName, "A", 800, []string{"192.168.0.4", "192.168.0.2"}),
+				PlanOnly: true,
+			},
+			// Renaming replaces the record set and removes the old one
+			{
+				Config: testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", renamedRecordsetName, "A", 800, []string{"192.168.0.2", "192.168.0.4"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "name", renamedRecordsetName),
+					func(s *terraform.State) error {
+						_, err := testAccClient(t).GetRecordSet(context.Background(), "localhost", "example.net.", recordsetName, "A")
+						if !powerdns.IsNotFound(err) {
+							return fmt.Errorf("expected record set '%s' to be deleted, got %v", recordsetName, err)
+						}
+						return nil
+					},
+				),
+			},
+			// A record set deleted outside of Terraform is removed from the
+			// state and created again
+			{
+				PreConfig: func() {
+					recordSet := &powerdns.RecordSet{Name: renamedRecordsetName, Type: "A"}
+					if err := testAccClient(t).DeleteRecordSet(context.Background(), "localhost", "example.net.", recordSet); err != nil {
+						t.Fatalf("unable to delete record set '%s': %v", renamedRecordsetName, err)
+					}
+				},
+				Config: testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", renamedRecordsetName, "A", 800, []string{"192.168.0.2", "192.168.0.4"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "records.#", "2"),
+					func(s *terraform.State) error {
+						recordSet, err := testAccClient(t).GetRecordSet(context.Background(), "localhost", "example.net.", renamedRecordsetName, "A")
+						if err != nil {
+							return fmt.Errorf("expected record set '%s' to be created again, got %v", renamedRecordsetName, err)
+						}
+						if len(recordSet.Records) != 2 {
+							return fmt.Errorf("expected 2 records, got %d", len(recordSet.Records))
+						}
+						return nil
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccPowerdnsRecordsetResourceComments(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with comments
+			{
+				Config: testAccPowerdnsRecordsetResourceCommentsConfig(recordsetName, 500, `
+  comments = [
+    { content = "managed by terraform", account = "ops" },
+  ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "comments.#", "1"),
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "comments.0.content", "managed by terraform"),
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "comments.0.account", "ops"),
+				),
+			},
+			// Comments are kept when they are not configured
+			{
+				Config: testAccPowerdnsRecordsetResourceCommentsConfig(recordsetName, 800, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "ttl", "800"),
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "comments.#", "1"),
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "comments.0.content", "managed by terraform"),
+				),
+			},
+			// An empty list removes all comments
+			{
+				Config: testAccPowerdnsRecordsetResourceCommentsConfig(recordsetName, 800, "comments = []"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "comments.#", "0"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -52,6 +156,239 @@ func TestAccPowerdnsRecordsetResource(t *testing.T) {
 	})
 }
 
+func TestAccPowerdnsRecordsetResourceCanonicalContent(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", recordsetName, "AAAA", 500, []string{"192.168.0.1"}),
+				ExpectError: regexp.MustCompile(`Content is not valid for a record of type AAAA`),
+			},
+			// Create with content the server stores in a different form
+			{
+				Config: testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", recordsetName, "AAAA", 500, []string{"2001:DB8::0001"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("powerdns_recordset.test", "records.*", "2001:DB8::0001"),
+				),
+			},
+			// The canonical form is semantically equal and must not cause a diff
+			{
+				Config:   testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", recordsetName, "AAAA", 500, []string{"2001:db8::1"}),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccPowerdnsRecordsetResourceQuoteTXT(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 300)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccPowerdnsRecordsetResourceQuoteTXTConfig(recordsetName, "A", `"192.168.0.1"`),
+				ExpectError: regexp.MustCompile(`"quote_txt" can only be set for TXT and SPF record sets`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsRecordsetResourceQuoteTXTConfig(recordsetName, "TXT", fmt.Sprintf(`"v=spf1 include:_spf.example.net -all", %q`, dkim)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "quote_txt", "true"),
+					resource.TestCheckTypeSetElemAttr("powerdns_recordset.test", "records.*", "v=spf1 include:_spf.example.net -all"),
+					resource.TestCheckTypeSetElemAttr("powerdns_recordset.test", "records.*", dkim),
+				),
+			},
+			// The plain values read back must not cause a diff
+			{
+				Config:   testAccPowerdnsRecordsetResourceQuoteTXTConfig(recordsetName, "TXT", fmt.Sprintf(`"v=spf1 include:_spf.example.net -all", %q`, dkim)),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccPowerdnsRecordsetResourceOverwriteExisting(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+	config := testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", recordsetName, "A", 60, []string{"192.168.0.1"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// A second resource must not take over the existing record set
+			{
+				Config:      config + testAccPowerdnsRecordsetResourceOverwriteConfig(recordsetName, ""),
+				ExpectError: regexp.MustCompile(`already exists`),
+			},
+			// unless explicitly requested
+			{
+				Config: config + testAccPowerdnsRecordsetResourceOverwriteConfig(recordsetName, "overwrite_existing = true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.other", "overwrite_existing", "true"),
+					resource.TestCheckTypeSetElemAttr("powerdns_recordset.other", "records.*", "192.168.0.1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccPowerdnsRecordsetResourceDefaultServerID(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The server id is inherited from the provider if unset
+			{
+				Config: fmt.Sprintf(`
+resource "powerdns_recordset" "test" {
+  zone_id = "example.net."
+  name = %[1]q
+  type = "A"
+  ttl = 60
+  records = ["192.168.0.1"]
+}
+`, recordsetName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "server_id", "localhost"),
+				),
+			},
+			// Setting the inherited server id explicitly must not cause a diff
+			{
+				Config:   testAccPowerdnsRecordsetResourceConfig("example.net.", "localhost", recordsetName, "A", 60, []string{"192.168.0.1"}),
+				PlanOnly: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccPowerdnsRecordsetResourceDisabledRecords(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccPowerdnsRecordsetResourceRecordBlocksConfig(recordsetName, false, `records = ["192.168.0.1"]`),
+				ExpectError: regexp.MustCompile(`Only one of "records" and "record" can be set`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsRecordsetResourceRecordBlocksConfig(recordsetName, false, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("powerdns_recordset.test", "records"),
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "record.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_recordset.test", "record.*", map[string]string{
+						"content":  "192.168.0.3",
+						"disabled": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_recordset.test", "record.*", map[string]string{
+						"content":  "192.168.0.4",
+						"disabled": "false",
+					}),
+				),
+			},
+			// Disable a single record
+			{
+				Config: testAccPowerdnsRecordsetResourceRecordBlocksConfig(recordsetName, true, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_recordset.test", "record.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_recordset.test", "record.*", map[string]string{
+						"content":  "192.168.0.3",
+						"disabled": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_recordset.test", "record.*", map[string]string{
+						"content":  "192.168.0.4",
+						"disabled": "true",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsRecordsetResourceRecordBlocksConfig(name string, disabled bool, extra string) string {
+	return fmt.Sprintf(`
+resource "powerdns_recordset" "test" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  type = "A"
+  ttl = 500
+  %[3]s
+
+  record {
+    content = "192.168.0.3"
+  }
+  record {
+    content = "192.168.0.4"
+    disabled = %[2]t
+  }
+}
+`, name, disabled, extra)
+}
+
+func testAccPowerdnsRecordsetResourceCommentsConfig(name string, ttl int64, comments string) string {
+	return fmt.Sprintf(`
+resource "powerdns_recordset" "test" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  type = "A"
+  ttl = %[2]d
+  records = ["192.168.0.3"]
+  %[3]s
+}
+`, name, ttl, comments)
+}
+
+func testAccPowerdnsRecordsetResourceQuoteTXTConfig(name, typ, records string) string {
+	return fmt.Sprintf(`
+resource "powerdns_recordset" "test" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  type = %[2]q
+  ttl = 60
+  quote_txt = true
+  records = [%[3]s]
+}
+`, name, typ, records)
+}
+
+func testAccPowerdnsRecordsetResourceOverwriteConfig(name, extra string) string {
+	return fmt.Sprintf(`
+resource "powerdns_recordset" "other" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  type = "A"
+  ttl = 60
+  records = ["192.168.0.1"]
+  %[2]s
+}
+`, name, extra)
+}
+
 func testAccPowerdnsRecordsetResourceConfig(zoneId, serverId, name, typ string, ttl int64, records []string) string {
 	recordBuilder := strings.Builder{}
 	for i, r := range records {
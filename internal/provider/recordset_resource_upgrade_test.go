@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRecordsetResourceUpgradeStateV0(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["powerdns"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// State as written by the released provider with schema version 0, which
+	// only knew these attributes.
+	rawState := []byte(`{
+  "id": "example.net./www.example.net./A",
+  "zone_id": "example.net.",
+  "server_id": "localhost",
+  "name": "www.example.net.",
+  "type": "A",
+  "ttl": 300,
+  "records": ["192.0.2.2", "192.0.2.1"]
+}`)
+
+	// The prior schema must describe exactly the released state.
+	var rawAttrs map[string]json.RawMessage
+	if err := json.Unmarshal(rawState, &rawAttrs); err != nil {
+		t.Fatal(err)
+	}
+	schemaV0 := recordsetResourceSchemaV0()
+	if len(schemaV0.Blocks) != 0 || len(schemaV0.Attributes) != len(rawAttrs) {
+		t.Errorf("expected schema version 0 to have exactly the attributes of the released state, got %d attributes and %d blocks", len(schemaV0.Attributes), len(schemaV0.Blocks))
+	}
+	for name := range rawAttrs {
+		if _, ok := schemaV0.Attributes[name]; !ok {
+			t.Errorf("schema version 0 is missing attribute %q", name)
+		}
+	}
+
+	resp, err := server.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "powerdns_recordset",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: rawState},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	RecordsetResource{}.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+	state, err := resp.UpgradedState.Unmarshal(schemaResp.Schema.Type().TerraformType(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	var records []tftypes.Value
+	if err := attrs["records"].As(&records); err != nil {
+		t.Fatal(err)
+	}
+	if !attrs["records"].Type().Is(tftypes.Set{}) || len(records) != 2 {
+		t.Errorf("expected records to be migrated to a set of 2 elements, got %s", attrs["records"])
+	}
+	if !attrs["comments"].IsNull() {
+		t.Errorf("expected comments to be null, got %s", attrs["comments"])
+	}
+	var recordBlocks []tftypes.Value
+	if err := attrs["record"].As(&recordBlocks); err != nil || len(recordBlocks) != 0 {
+		t.Errorf("expected no record blocks, got %s", attrs["record"])
+	}
+	for _, name := range []string{"quote_txt", "overwrite_existing"} {
+		if !attrs[name].Equal(tftypes.NewValue(tftypes.Bool, false)) {
+			t.Errorf("expected %s to be false, got %s", name, attrs[name])
+		}
+	}
+	if !attrs["ttl"].Equal(tftypes.NewValue(tftypes.Number, 300)) {
+		t.Errorf("expected ttl to be kept, got %s", attrs["ttl"])
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultServerID is the id of the only server PowerDNS knows.
+const defaultServerID = "localhost"
+
+const serverIDDescription = "The id of the server. Defaults to the `server_id` of the provider."
+
+// serverIDAttribute returns the schema of the server_id attribute of
+// resources. If it isn't configured, the provider's server id is planned by
+// planServerID. Resources keep their server id when the provider's changes.
+func serverIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: serverIDDescription,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// serverIDDataSourceAttribute returns the schema of the server_id attribute
+// of data sources.
+func serverIDDataSourceAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		MarkdownDescription: serverIDDescription,
+		Optional:            true,
+		Computed:            true,
+	}
+}
+
+// planServerID plans the provider's server id for resources without a
+// configured server_id. The attribute stays unknown if the provider hasn't
+// been configured yet.
+func planServerID(ctx context.Context, client *powerdns.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+
+	var serverID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("server_id"), &serverID)...)
+	if resp.Diagnostics.HasError() || !serverID.IsUnknown() {
+		return
+	}
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("server_id"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("server_id"), client.ServerID())...)
+}
+
+// serverIDOrDefault returns serverID, or the provider's server id if it is
+// not set.
+func serverIDOrDefault(client *powerdns.Client, serverID types.String) types.String {
+	if !serverID.IsNull() || client == nil {
+		return serverID
+	}
+	return types.StringValue(client.ServerID())
+}
+
+// importServerID returns the server id for import IDs without one.
+func importServerID(client *powerdns.Client) string {
+	if client == nil {
+		return defaultServerID
+	}
+	return client.ServerID()
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var srvRecordAttrTypes = map[string]attr.Type{
+	"priority": types.Int64Type,
+	"weight":   types.Int64Type,
+	"port":     types.Int64Type,
+	"target":   types.StringType,
+}
+
+func NewSRVRecordsetResource() resource.Resource {
+	return &TypedRecordsetResource{
+		typedRecordset: typedRecordset{
+			rrType:   "SRV",
+			typeName: "_srv_recordset",
+			records: schema.SetNestedAttribute{
+				MarkdownDescription: "All SRV records in this record set.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"priority": schema.Int64Attribute{
+							MarkdownDescription: "Priority of the target, lower values are preferred.",
+							Required:            true,
+						},
+						"weight": schema.Int64Attribute{
+							MarkdownDescription: "Relative weight of targets with the same priority.",
+							Required:            true,
+						},
+						"port": schema.Int64Attribute{
+							MarkdownDescription: "Port of the service on the target.",
+							Required:            true,
+						},
+						"target": schema.StringAttribute{
+							MarkdownDescription: "Hostname of the target (e.g. \"sip.example.com.\").",
+							Required:            true,
+						},
+					},
+				},
+			},
+			elementType: types.ObjectType{AttrTypes: srvRecordAttrTypes},
+			toContent: func(v attr.Value) string {
+				attrs := v.(types.Object).Attributes()
+				return fmt.Sprintf("%d %d %d %s",
+					attrs["priority"].(types.Int64).ValueInt64(),
+					attrs["weight"].(types.Int64).ValueInt64(),
+					attrs["port"].(types.Int64).ValueInt64(),
+					attrs["target"].(types.String).ValueString())
+			},
+			fromContent: func(content string) (attr.Value, error) {
+				fields := strings.Fields(content)
+				if len(fields) != 4 {
+					return nil, fmt.Errorf("expected <priority> <weight> <port> <target>")
+				}
+				numbers := make([]int64, 3)
+				for i := range numbers {
+					n, err := strconv.ParseInt(fields[i], 10, 64)
+					if err != nil {
+						return nil, err
+					}
+					numbers[i] = n
+				}
+				return types.ObjectValueMust(srvRecordAttrTypes, map[string]attr.Value{
+					"priority": types.Int64Value(numbers[0]),
+					"weight":   types.Int64Value(numbers[1]),
+					"port":     types.Int64Value(numbers[2]),
+					"target":   types.StringValue(fields[3]),
+				}), nil
+			},
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsSRVRecordsetResource(t *testing.T) {
+	recordsetName := "_sip._tcp." + randomRecordsetName(5, "example.net.")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsSRVRecordsetResourceConfig(recordsetName, 5060),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_srv_recordset.test", "records.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_srv_recordset.test", "records.*", map[string]string{
+						"priority": "10",
+						"weight":   "60",
+						"port":     "5060",
+						"target":   "sip1.example.net.",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerdns_srv_recordset.test",
+				ImportStateId:     fmt.Sprintf("localhost/example.net./%s", recordsetName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPowerdnsSRVRecordsetResourceConfig(recordsetName, 5061),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("powerdns_srv_recordset.test", "records.*", map[string]string{
+						"port":   "5061",
+						"target": "sip2.example.net.",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsSRVRecordsetResourceConfig(name string, port int64) string {
+	return fmt.Sprintf(`
+resource "powerdns_srv_recordset" "test" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  ttl = 300
+  records = [
+    { priority = 10, weight = 60, port = %[2]d, target = "sip1.example.net." },
+    { priority = 10, weight = 40, port = %[2]d, target = "sip2.example.net." },
+  ]
+}
+`, name, port)
+}
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &TSIGKeyResource{}
+var _ resource.ResourceWithImportState = &TSIGKeyResource{}
+var _ resource.ResourceWithValidateConfig = &TSIGKeyResource{}
+var _ resource.ResourceWithModifyPlan = &TSIGKeyResource{}
+
+func NewTSIGKeyResource() resource.Resource {
+	return &TSIGKeyResource{}
+}
+
+type TSIGKeyResource struct {
+	client *powerdns.Client
+}
+
+type TSIGKeyResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	ServerId     types.String `tfsdk:"server_id"`
+	Name         types.String `tfsdk:"name"`
+	Algorithm    types.String `tfsdk:"algorithm"`
+	Key          types.String `tfsdk:"key"`
+	KeyWO        types.String `tfsdk:"key_wo"`
+	KeyWOVersion types.Int64  `tfsdk:"key_wo_version"`
+}
+
+func (r *TSIGKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tsigkey"
+}
+
+func (r *TSIGKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "PowerDNS TSIG Key",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the key, assigned by the server.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_id": serverIDAttribute(),
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the key.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "The algorithm of the key (e.g. \"hmac-sha256\"). The server default is used if unset.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The Base64 encoded secret key. Generated by the server if neither `key` nor `key_wo` are set. " +
+					"Stays empty if `key_wo` is used, so the secret is not stored in the state. " +
+					"Note that importing a key always stores its secret here, as the import can't know whether `key_wo` is used. " +
+					"With `key_wo`, the secret is removed from the state again by the next apply.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_wo": schema.StringAttribute{
+				MarkdownDescription: "The Base64 encoded secret key, which is never stored in the state. Requires `key_wo_version`.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"key_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `key_wo`. The key is only sent to the server when this value changes.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *TSIGKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TSIGKeyResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.KeyWO.IsNull() && !data.Key.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key_wo"),
+			"Conflicting Attributes",
+			"Only one of \"key\" and \"key_wo\" can be set.",
+		)
+	}
+	if !data.KeyWO.IsNull() && data.KeyWOVersion.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key_wo_version"),
+			"Missing Attribute",
+			"\"key_wo_version\" must be set together with \"key_wo\".",
+		)
+	}
+	if data.KeyWO.IsNull() && !data.KeyWOVersion.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key_wo"),
+			"Missing Attribute",
+			"\"key_wo\" must be set together with \"key_wo_version\".",
+		)
+	}
+}
+
+func (r *TSIGKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planServerID(ctx, r.client, req, resp)
+
+	var plan TSIGKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The secret of write-only keys is never stored in the state.
+	if !plan.KeyWOVersion.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("key"), types.StringNull())...)
+	}
+}
+
+func (r *TSIGKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerdns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *powerdns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TSIGKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TSIGKeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	// Write-only attributes are only available in the configuration
+	var keyWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("key_wo"), &keyWO)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := &powerdns.TSIGKey{
+		Name:      data.Name.ValueString(),
+		Algorithm: data.Algorithm.ValueString(),
+		Key:       data.Key.ValueString(),
+	}
+	if !keyWO.IsNull() {
+		key.Key = keyWO.ValueString()
+	}
+
+	serverId := data.ServerId.ValueString()
+	tflog.Debug(ctx, "Creating TSIG key", map[string]interface{}{
+		"server_id": serverId,
+		"name":      key.Name,
+		"algorithm": key.Algorithm,
+	})
+	key, err := r.client.CreateTSIGKey(ctx, serverId, key)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create TSIG key '%s': %v", data.Name.ValueString(), err))
+		return
+	}
+
+	tsigKeyObjectToResourceData(key, &data)
+	tflog.Debug(ctx, "Created TSIG key", map[string]interface{}{
+		"id":        data.Id.ValueString(),
+		"server_id": serverId,
+		"name":      data.Name.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TSIGKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TSIGKeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.Id.ValueString()
+	serverId := data.ServerId.ValueString()
+	tflog.Debug(ctx, "Reading TSIG key", map[string]interface{}{
+		"id":        id,
+		"server_id": serverId,
+	})
+	key, err := r.client.GetTSIGKey(ctx, serverId, id)
+	if powerdns.IsNotFound(err) {
+		tflog.Warn(ctx, "TSIG key not found, removing it from state", map[string]interface{}{
+			"id":        id,
+			"server_id": serverId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get TSIG key '%s': %v", id, err))
+		return
+	}
+
+	tsigKeyObjectToResourceData(key, &data)
+	tflog.Debug(ctx, "Read TSIG key", map[string]interface{}{
+		"id":        data.Id.ValueString(),
+		"server_id": serverId,
+		"name":      data.Name.ValueString(),
+		"algorithm": data.Algorithm.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TSIGKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state TSIGKeyResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	// Write-only attributes are only available in the configuration
+	var keyWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("key_wo"), &keyWO)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := &powerdns.TSIGKey{Algorithm: data.Algorithm.ValueString()}
+	if !data.Key.Equal(state.Key) {
+		key.Key = data.Key.ValueString()
+	}
+	if !data.KeyWOVersion.Equal(state.KeyWOVersion) {
+		key.Key = keyWO.ValueString()
+	}
+
+	id := state.Id.ValueString()
+	serverId := data.ServerId.ValueString()
+	tflog.Debug(ctx, "Updating TSIG key", map[string]interface{}{
+		"id":          id,
+		"server_id":   serverId,
+		"algorithm":   key.Algorithm,
+		"key_changed": key.Key != "",
+	})
+	key, err := r.client.UpdateTSIGKey(ctx, serverId, id, key)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update TSIG key '%s': %v", id, err))
+		return
+	}
+
+	key, err = r.client.GetTSIGKey(ctx, serverId, key.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get TSIG key '%s': %v", id, err))
+		return
+	}
+
+	tsigKeyObjectToResourceData(key, &data)
+	tflog.Debug(ctx, "Updated TSIG key", map[string]interface{}{
+		"id":        data.Id.ValueString(),
+		"server_id": serverId,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TSIGKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TSIGKeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.Id.ValueString()
+	serverId := data.ServerId.ValueString()
+	tflog.Debug(ctx, "Deleting TSIG key", map[string]interface{}{
+		"id":        id,
+		"server_id": serverId,
+	})
+	if err := r.client.DeleteTSIGKey(ctx, serverId, id); err != nil && !powerdns.IsNotFound(err) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete TSIG key '%s': %v", id, err))
+		return
+	}
+	tflog.Debug(ctx, "Deleted TSIG key", map[string]interface{}{
+		"id":        id,
+		"server_id": serverId,
+	})
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState imports a TSIG key. The following Read stores the secret in
+// "key", since key_wo_version is not known yet. With key_wo, the next apply
+// sets key_wo_version and removes the secret from the state again.
+func (r *TSIGKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fields, err := parseImportID(req.ID, importServerID(r.client), "server_id", "tsigkey_id")
+	if err != nil {
+		resp.Diagnostics.AddError("Resource Import ID invalid", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fields[1])...)
+}
+
+func tsigKeyObjectToResourceData(key *powerdns.TSIGKey, data *TSIGKeyResourceModel) {
+	data.Id = types.StringValue(key.ID)
+	// The server returns the name without trailing dot and the algorithm in
+	// lower case, keep the configured spelling to avoid a diff.
+	if strings.TrimSuffix(data.Name.ValueString(), ".") != strings.TrimSuffix(key.Name, ".") {
+		data.Name = types.StringValue(key.Name)
+	}
+	if !strings.EqualFold(data.Algorithm.ValueString(), key.Algorithm) {
+		data.Algorithm = types.StringValue(key.Algorithm)
+	}
+	// The secret of write-only keys is never stored in the state.
+	if data.KeyWOVersion.IsNull() {
+		data.Key = types.StringValue(key.Key)
+	} else {
+		data.Key = types.StringNull()
+	}
+	data.KeyWO = types.StringNull()
+}
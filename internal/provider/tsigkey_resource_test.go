@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPowerdnsTSIGKeyResource(t *testing.T) {
+	keyName := randomZoneName(12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing with a server generated key
+			{
+				Config: testAccPowerdnsTSIGKeyResourceConfig(keyName, "hmac-sha256", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_tsigkey.test", "server_id", "localhost"),
+					resource.TestCheckResourceAttr("powerdns_tsigkey.test", "name", keyName),
+					resource.TestCheckResourceAttr("powerdns_tsigkey.test", "algorithm", "hmac-sha256"),
+					resource.TestCheckResourceAttrSet("powerdns_tsigkey.test", "id"),
+					resource.TestCheckResourceAttrSet("powerdns_tsigkey.test", "key"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerdns_tsigkey.test",
+				ImportStateId:     "localhost/" + keyName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPowerdnsTSIGKeyResourceConfig(keyName, "hmac-sha512", `key = "dGVzdGtleQ=="`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_tsigkey.test", "algorithm", "hmac-sha512"),
+					resource.TestCheckResourceAttr("powerdns_tsigkey.test", "key", "dGVzdGtleQ=="),
+				),
+			},
+			// Write-only key testing
+			{
+				Config: testAccPowerdnsTSIGKeyResourceConfig(keyName, "hmac-sha512", `
+  key_wo = "c2VjcmV0a2V5"
+  key_wo_version = 1`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("powerdns_tsigkey.test", "key"),
+					resource.TestCheckNoResourceAttr("powerdns_tsigkey.test", "key_wo"),
+					resource.TestCheckResourceAttr("powerdns_tsigkey.test", "key_wo_version", "1"),
+				),
+			},
+			// Forget the key without deleting it, to import it again
+			{
+				Config: `
+removed {
+  from = powerdns_tsigkey.test
+  lifecycle {
+    destroy = false
+  }
+}
+`,
+			},
+			// Importing stores the secret, even if key_wo is used
+			{
+				Config: testAccPowerdnsTSIGKeyResourceConfig(keyName, "hmac-sha512", `
+  key_wo = "c2VjcmV0a2V5"
+  key_wo_version = 1`),
+				ResourceName:       "powerdns_tsigkey.test",
+				ImportStateId:      "localhost/" + keyName,
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["key"] != "c2VjcmV0a2V5" {
+						return fmt.Errorf("expected the imported state to contain the secret, got %v", states)
+					}
+					return nil
+				},
+			},
+			// The next apply removes the secret from the state again
+			{
+				Config: testAccPowerdnsTSIGKeyResourceConfig(keyName, "hmac-sha512", `
+  key_wo = "c2VjcmV0a2V5"
+  key_wo_version = 1`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("powerdns_tsigkey.test", "key"),
+					resource.TestCheckResourceAttr("powerdns_tsigkey.test", "key_wo_version", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsTSIGKeyResourceConfig(name, algorithm, extra string) string {
+	return fmt.Sprintf(`
+resource "powerdns_tsigkey" "test" {
+  server_id = "localhost"
+  name = %[1]q
+  algorithm = %[2]q
+  %[3]s
+}
+`, name, algorithm, extra)
+}
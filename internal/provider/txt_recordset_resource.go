@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewTXTRecordsetResource() resource.Resource {
+	return &TypedRecordsetResource{
+		typedRecordset: typedRecordset{
+			rrType:   "TXT",
+			typeName: "_txt_recordset",
+			records: schema.SetAttribute{
+				MarkdownDescription: "All TXT records in this record set, as plain text. Values are quoted and escaped, and values longer than 255 bytes are split into multiple strings automatically.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			elementType: types.StringType,
+			toContent: func(v attr.Value) string {
+				return powerdns.QuoteTXT(v.(types.String).ValueString())
+			},
+			fromContent: func(content string) (attr.Value, error) {
+				value, err := powerdns.UnquoteTXT(content)
+				if err != nil {
+					return nil, err
+				}
+				return types.StringValue(value), nil
+			},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsTXTRecordsetResource(t *testing.T) {
+	recordsetName := randomRecordsetName(5, "example.net.")
+	// Longer than a single character string of 255 bytes.
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsTXTRecordsetResourceConfig(recordsetName, []string{`v=spf1 include:"example.net" -all`, dkim}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_txt_recordset.test", "records.#", "2"),
+					resource.TestCheckTypeSetElemAttr("powerdns_txt_recordset.test", "records.*", `v=spf1 include:"example.net" -all`),
+					resource.TestCheckTypeSetElemAttr("powerdns_txt_recordset.test", "records.*", dkim),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerdns_txt_recordset.test",
+				ImportStateId:     fmt.Sprintf("localhost/example.net./%s", recordsetName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPowerdnsTXTRecordsetResourceConfig(recordsetName, []string{"v=spf1 -all"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_txt_recordset.test", "records.#", "1"),
+					resource.TestCheckTypeSetElemAttr("powerdns_txt_recordset.test", "records.*", "v=spf1 -all"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsTXTRecordsetResourceConfig(name string, records []string) string {
+	quoted := make([]string, len(records))
+	for i, r := range records {
+		quoted[i] = fmt.Sprintf("%q", r)
+	}
+	return fmt.Sprintf(`
+resource "powerdns_txt_recordset" "test" {
+  zone_id = "example.net."
+  server_id = "localhost"
+  name = %[1]q
+  ttl = 300
+  records = [%[2]s]
+}
+`, name, strings.Join(quoted, ", "))
+}
@@ -0,0 +1,430 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &TypedRecordsetResource{}
+var _ resource.ResourceWithImportState = &TypedRecordsetResource{}
+var _ resource.ResourceWithModifyPlan = &TypedRecordsetResource{}
+var _ resource.ResourceWithValidateConfig = &TypedRecordsetResource{}
+
+// typedRecordset describes a record type whose records are managed as
+// structured values instead of opaque content strings.
+type typedRecordset struct {
+	// rrType is the type of the record set, e.g. "MX".
+	rrType string
+	// typeName is appended to the provider type name to get the resource
+	// type name, e.g. "_mx_recordset".
+	typeName string
+	// records is the schema of the records attribute, a set of elementType.
+	records     schema.Attribute
+	elementType attr.Type
+	// toContent and fromContent convert between a structured record and
+	// its content.
+	toContent   func(attr.Value) string
+	fromContent func(string) (attr.Value, error)
+}
+
+// TypedRecordsetResource manages a record set of a single record type, using
+// the same API calls as RecordsetResource.
+type TypedRecordsetResource struct {
+	client *powerdns.Client
+	typedRecordset
+}
+
+type TypedRecordsetResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	ZoneId            types.String `tfsdk:"zone_id"`
+	ServerId          types.String `tfsdk:"server_id"`
+	Name              types.String `tfsdk:"name"`
+	Ttl               types.Int64  `tfsdk:"ttl"`
+	Records           types.Set    `tfsdk:"records"`
+	OverwriteExisting types.Bool   `tfsdk:"overwrite_existing"`
+}
+
+func (r *TypedRecordsetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeName
+}
+
+func (r *TypedRecordsetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("PowerDNS %s Record Set", r.rrType),
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "State ID for the record set (only needed for internal technical purposes).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the zone this record set belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"server_id": serverIDAttribute(),
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name for record set (e.g. \"www.powerdns.com.\")",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					fqdn(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "DNS TTL of the records, in seconds.",
+				Required:            true,
+			},
+			"records":            r.records,
+			"overwrite_existing": overwriteExistingAttribute(),
+		},
+	}
+}
+
+func (r *TypedRecordsetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.client, req, resp)
+}
+
+func (r *TypedRecordsetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerdns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *powerdns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TypedRecordsetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TypedRecordsetResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateNameInZone(data.Name, data.ZoneId, &resp.Diagnostics)
+
+	if data.Records.IsUnknown() {
+		return
+	}
+
+	for _, record := range data.Records.Elements() {
+		if !isFullyKnown(record) {
+			continue
+		}
+
+		if err := powerdns.ValidateContent(r.rrType, r.toContent(record)); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records").AtSetValue(record),
+				"Invalid Record",
+				fmt.Sprintf("Record is not valid for a record of type %s: %v", r.rrType, err),
+			)
+		}
+	}
+}
+
+func (r *TypedRecordsetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TypedRecordsetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordset := &powerdns.RecordSet{}
+	r.resourceDataToObject(data, recordset)
+
+	zoneId := data.ZoneId.ValueString()
+	serverId := data.ServerId.ValueString()
+
+	if !data.OverwriteExisting.ValueBool() {
+		importID := formatImportID(serverId, zoneId, recordset.Name)
+		checkRecordSetAbsent(ctx, r.client, serverId, zoneId, recordset.Name, r.rrType, importID, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Creating record set", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      recordset.Name,
+		"type":      recordset.Type,
+		"ttl":       recordset.TTL,
+		"records":   recordset.Records,
+	})
+	recordset, err := r.client.CreateRecordSet(ctx, serverId, zoneId, recordset)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create record set '%s': %v", data.Name.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.objectToResourceData(recordset, &data)...)
+	tflog.Debug(ctx, "Created record set", map[string]interface{}{
+		"id":        data.Id.ValueString(),
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      recordset.Name,
+		"type":      recordset.Type,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TypedRecordsetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TypedRecordsetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := data.ZoneId.ValueString()
+	serverId := data.ServerId.ValueString()
+	recordSetName := data.Name.ValueString()
+	tflog.Debug(ctx, "Reading record set", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      recordSetName,
+		"type":      r.rrType,
+	})
+	recordset, err := r.client.GetRecordSet(ctx, serverId, zoneId, recordSetName, r.rrType)
+	if powerdns.IsNotFound(err) {
+		tflog.Warn(ctx, "Record set not found, removing it from state", map[string]interface{}{
+			"zone_id":   zoneId,
+			"server_id": serverId,
+			"name":      recordSetName,
+			"type":      r.rrType,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get record set '%s' (type '%s'): %v", recordSetName, r.rrType, err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.objectToResourceData(recordset, &data)...)
+	tflog.Debug(ctx, "Read record set", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      recordSetName,
+		"type":      r.rrType,
+		"ttl":       recordset.TTL,
+		"records":   recordset.Records,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TypedRecordsetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TypedRecordsetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordset := &powerdns.RecordSet{}
+	r.resourceDataToObject(data, recordset)
+
+	zoneId := data.ZoneId.ValueString()
+	serverId := data.ServerId.ValueString()
+	tflog.Debug(ctx, "Updating record set", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      recordset.Name,
+		"type":      recordset.Type,
+		"ttl":       recordset.TTL,
+		"records":   recordset.Records,
+	})
+	if err := r.client.UpdateRecordSet(ctx, serverId, zoneId, recordset); err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update record set '%s': %v", recordset.Name, err))
+		return
+	}
+
+	recordset, err := r.client.GetRecordSet(ctx, serverId, zoneId, recordset.Name, r.rrType)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get record set '%s': %v", data.Name.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.objectToResourceData(recordset, &data)...)
+	tflog.Debug(ctx, "Updated record set", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      recordset.Name,
+		"type":      recordset.Type,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TypedRecordsetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TypedRecordsetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordset := &powerdns.RecordSet{
+		Name: data.Name.ValueString(),
+		Type: r.rrType,
+	}
+
+	zoneId := data.ZoneId.ValueString()
+	serverId := data.ServerId.ValueString()
+	tflog.Debug(ctx, "Deleting record set", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      recordset.Name,
+		"type":      recordset.Type,
+	})
+	if err := r.client.DeleteRecordSet(ctx, serverId, zoneId, recordset); err != nil && !powerdns.IsNotFound(err) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete record set '%s': %v", recordset.Name, err))
+		return
+	}
+	tflog.Debug(ctx, "Deleted record set", map[string]interface{}{
+		"zone_id":   zoneId,
+		"server_id": serverId,
+		"name":      recordset.Name,
+		"type":      recordset.Type,
+	})
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *TypedRecordsetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fields, err := parseImportID(req.ID, importServerID(r.client), "server_id", "zone_id", "recordset_name")
+	if err != nil {
+		resp.Diagnostics.AddError("Resource Import ID invalid", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), importZoneID(fields[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), importName(fields[2]))...)
+}
+
+func (r *TypedRecordsetResource) resourceDataToObject(data TypedRecordsetResourceModel, recordset *powerdns.RecordSet) {
+	recordset.Name = data.Name.ValueString()
+	recordset.Type = r.rrType
+	recordset.TTL = data.Ttl.ValueInt64()
+	recordset.Records = nil
+
+	for _, record := range data.Records.Elements() {
+		recordset.Records = append(recordset.Records, powerdns.Record{Content: r.toContent(record)})
+	}
+}
+
+// objectToResourceData stores recordset in data. Records which are
+// semantically equal to a record in data are kept as they are.
+func (r *TypedRecordsetResource) objectToResourceData(recordset *powerdns.RecordSet, data *TypedRecordsetResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var known []attr.Value
+	if !data.Records.IsNull() && !data.Records.IsUnknown() {
+		known = data.Records.Elements()
+	}
+
+	records := make([]attr.Value, 0, len(recordset.Records))
+	for _, record := range recordset.Records {
+		value, err := r.knownRecord(record.Content, known)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("records"),
+				"Unexpected Record Content",
+				fmt.Sprintf("Unable to parse content '%s' of %s record set '%s': %v", record.Content, r.rrType, recordset.Name, err),
+			)
+			continue
+		}
+		records = append(records, value)
+	}
+
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", data.ZoneId.ValueString(), data.Name.ValueString(), r.rrType))
+	data.Name = types.StringValue(recordset.Name)
+	data.Ttl = types.Int64Value(recordset.TTL)
+	if data.OverwriteExisting.IsNull() || data.OverwriteExisting.IsUnknown() {
+		data.OverwriteExisting = types.BoolValue(false)
+	}
+
+	var d diag.Diagnostics
+	data.Records, d = types.SetValue(r.elementType, records)
+	diags.Append(d...)
+
+	return diags
+}
+
+// knownRecord returns the element of known which is semantically equal to
+// content, or the record parsed from content if there is none.
+func (r *TypedRecordsetResource) knownRecord(content string, known []attr.Value) (attr.Value, error) {
+	for _, k := range known {
+		if !isFullyKnown(k) {
+			continue
+		}
+		if powerdns.SameContent(r.rrType, r.toContent(k), content) {
+			return k, nil
+		}
+	}
+	return r.fromContent(content)
+}
+
+// isFullyKnown reports whether v and, for objects, all of its attributes are
+// known and not null.
+func isFullyKnown(v attr.Value) bool {
+	if v.IsNull() || v.IsUnknown() {
+		return false
+	}
+	if obj, ok := v.(types.Object); ok {
+		for _, a := range obj.Attributes() {
+			if a.IsNull() || a.IsUnknown() {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,454 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ZoneCryptokeyResource{}
+var _ resource.ResourceWithImportState = &ZoneCryptokeyResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneCryptokeyResource{}
+
+func NewZoneCryptokeyResource() resource.Resource {
+	return &ZoneCryptokeyResource{}
+}
+
+type ZoneCryptokeyResource struct {
+	client *powerdns.Client
+}
+
+type ZoneCryptokeyResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	ServerId  types.String `tfsdk:"server_id"`
+	ZoneId    types.String `tfsdk:"zone_id"`
+	KeyId     types.Int64  `tfsdk:"key_id"`
+	KeyType   types.String `tfsdk:"keytype"`
+	Active    types.Bool   `tfsdk:"active"`
+	Published types.Bool   `tfsdk:"published"`
+	Algorithm types.String `tfsdk:"algorithm"`
+	Bits      types.Int64  `tfsdk:"bits"`
+	DNSKey    types.String `tfsdk:"dnskey"`
+	DS        types.List   `tfsdk:"ds"`
+	CDS       types.List   `tfsdk:"cds"`
+}
+
+func (r *ZoneCryptokeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_cryptokey"
+}
+
+func (r *ZoneCryptokeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "PowerDNS DNSSEC Cryptokey of a Zone",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "State ID for the cryptokey (only needed for internal technical purposes).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_id": serverIDAttribute(),
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the zone this cryptokey belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.Int64Attribute{
+				MarkdownDescription: "The internal identifier of the key, assigned by the server.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"keytype": schema.StringAttribute{
+				MarkdownDescription: "Type of the key, one of \"ksk\", \"zsk\", \"csk\".",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					keyType(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not the key is in active use. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"published": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not the DNSKEY record is published in the zone. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Mnemonic of the key's algorithm, one of \"RSASHA1\", \"RSASHA1-NSEC3-SHA1\", \"RSASHA256\", \"RSASHA512\", " +
+					"\"ECDSAP256SHA256\", \"ECDSAP384SHA384\", \"ED25519\" or \"ED448\". Algorithm numbers are not accepted. The server default is used if unset.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					dnssecAlgorithm(),
+				},
+			},
+			"bits": schema.Int64Attribute{
+				MarkdownDescription: "The size of the key. The default of the algorithm is used if unset.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"dnskey": schema.StringAttribute{
+				MarkdownDescription: "The DNSKEY record for this key.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ds": schema.ListAttribute{
+				MarkdownDescription: "DS records for this key, to be published in the parent zone.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cds": schema.ListAttribute{
+				MarkdownDescription: "DS records for this key, filtered by the CDS publication settings of the zone.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ZoneCryptokeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.client, req, resp)
+}
+
+func (r *ZoneCryptokeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerdns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *powerdns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneCryptokeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneCryptokeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := &powerdns.Cryptokey{}
+	zoneCryptokeyResourceDataToObject(data, key)
+
+	serverId := data.ServerId.ValueString()
+	zoneId := data.ZoneId.ValueString()
+	tflog.Debug(ctx, "Creating cryptokey", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"keytype":   key.KeyType,
+		"algorithm": key.Algorithm,
+		"bits":      key.Bits,
+	})
+	key, err := r.client.CreateCryptokey(ctx, serverId, zoneId, key)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create cryptokey for zone '%s': %v", zoneId, err))
+		return
+	}
+
+	resp.Diagnostics.Append(zoneCryptokeyObjectToResourceData(ctx, key, &data)...)
+	tflog.Debug(ctx, "Created cryptokey", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"key_id":    data.KeyId.ValueInt64(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneCryptokeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneCryptokeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverId := data.ServerId.ValueString()
+	zoneId := data.ZoneId.ValueString()
+	keyId := data.KeyId.ValueInt64()
+	tflog.Debug(ctx, "Reading cryptokey", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"key_id":    keyId,
+	})
+	key, err := r.client.GetCryptokey(ctx, serverId, zoneId, keyId)
+	if powerdns.IsNotFound(err) {
+		tflog.Warn(ctx, "Cryptokey not found, removing it from state", map[string]interface{}{
+			"server_id": serverId,
+			"zone_id":   zoneId,
+			"key_id":    keyId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get cryptokey %d of zone '%s': %v", keyId, zoneId, err))
+		return
+	}
+
+	resp.Diagnostics.Append(zoneCryptokeyObjectToResourceData(ctx, key, &data)...)
+	tflog.Debug(ctx, "Read cryptokey", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"key_id":    keyId,
+		"active":    key.Active,
+		"published": key.Published,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneCryptokeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ZoneCryptokeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key := &powerdns.Cryptokey{}
+	zoneCryptokeyResourceDataToObject(data, key)
+
+	serverId := data.ServerId.ValueString()
+	zoneId := data.ZoneId.ValueString()
+	tflog.Debug(ctx, "Updating cryptokey", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"key_id":    key.ID,
+		"active":    key.Active,
+		"published": key.Published,
+	})
+	if err := r.client.UpdateCryptokey(ctx, serverId, zoneId, key); err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update cryptokey %d of zone '%s': %v", key.ID, zoneId, err))
+		return
+	}
+
+	key, err := r.client.GetCryptokey(ctx, serverId, zoneId, key.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get cryptokey %d of zone '%s': %v", data.KeyId.ValueInt64(), zoneId, err))
+		return
+	}
+
+	resp.Diagnostics.Append(zoneCryptokeyObjectToResourceData(ctx, key, &data)...)
+	tflog.Debug(ctx, "Updated cryptokey", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"key_id":    key.ID,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneCryptokeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneCryptokeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverId := data.ServerId.ValueString()
+	zoneId := data.ZoneId.ValueString()
+	keyId := data.KeyId.ValueInt64()
+	tflog.Debug(ctx, "Deleting cryptokey", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"key_id":    keyId,
+	})
+	if err := r.client.DeleteCryptokey(ctx, serverId, zoneId, keyId); err != nil && !powerdns.IsNotFound(err) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete cryptokey %d of zone '%s': %v", keyId, zoneId, err))
+		return
+	}
+	tflog.Debug(ctx, "Deleted cryptokey", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"key_id":    keyId,
+	})
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ZoneCryptokeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fields, err := parseImportID(req.ID, importServerID(r.client), "server_id", "zone_id", "key_id")
+	if err != nil {
+		resp.Diagnostics.AddError("Resource Import ID invalid", err.Error())
+		return
+	}
+
+	keyID, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Resource Import ID invalid",
+			fmt.Sprintf("Key ID '%s' in ID '%s' is not a number", fields[2], req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), importZoneID(fields[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_id"), keyID)...)
+}
+
+func zoneCryptokeyResourceDataToObject(data ZoneCryptokeyResourceModel, key *powerdns.Cryptokey) {
+	key.ID = data.KeyId.ValueInt64()
+	key.KeyType = data.KeyType.ValueString()
+	key.Active = data.Active.ValueBool()
+	key.Published = data.Published.ValueBool()
+	key.Algorithm = data.Algorithm.ValueString()
+	key.Bits = data.Bits.ValueInt64()
+}
+
+func zoneCryptokeyObjectToResourceData(ctx context.Context, key *powerdns.Cryptokey, data *ZoneCryptokeyResourceModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s/%d", data.ServerId.ValueString(), data.ZoneId.ValueString(), key.ID))
+	data.KeyId = types.Int64Value(key.ID)
+	// The server reports the key type in lower and the algorithm in upper
+	// case, keep the configured spelling to avoid a diff.
+	if !strings.EqualFold(data.KeyType.ValueString(), key.KeyType) {
+		data.KeyType = types.StringValue(key.KeyType)
+	}
+	data.Active = types.BoolValue(key.Active)
+	data.Published = types.BoolValue(key.Published)
+	if !strings.EqualFold(data.Algorithm.ValueString(), key.Algorithm) {
+		data.Algorithm = types.StringValue(key.Algorithm)
+	}
+	data.Bits = types.Int64Value(key.Bits)
+	data.DNSKey = types.StringValue(key.DNSKey)
+	data.DS, d = types.ListValueFrom(ctx, types.StringType, nonNilStrings(key.DS))
+	diags.Append(d...)
+	data.CDS, d = types.ListValueFrom(ctx, types.StringType, nonNilStrings(key.CDS))
+	diags.Append(d...)
+
+	return diags
+}
+
+// nonNilStrings returns an empty slice for nil, so that lists which are
+// omitted by the server end up as empty instead of null lists in the state.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// keyType returns a validator which checks that a cryptokey type is known to
+// PowerDNS.
+func keyType() validator.String {
+	return keyTypeValidator{}
+}
+
+type keyTypeValidator struct{}
+
+func (v keyTypeValidator) Description(ctx context.Context) string {
+	return "key type must be one of \"ksk\", \"zsk\" or \"csk\""
+}
+
+func (v keyTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v keyTypeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := powerdns.ValidateKeyType(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Key Type", err.Error())
+	}
+}
+
+// dnssecAlgorithm returns a validator which checks that an algorithm is the
+// mnemonic of a DNSSEC algorithm supported by PowerDNS. Algorithm numbers are
+// rejected, since the server reports the mnemonic and the key would be
+// replaced on every plan.
+func dnssecAlgorithm() validator.String {
+	return dnssecAlgorithmValidator{}
+}
+
+type dnssecAlgorithmValidator struct{}
+
+func (v dnssecAlgorithmValidator) Description(ctx context.Context) string {
+	return "algorithm must be the mnemonic of a DNSSEC algorithm supported by PowerDNS"
+}
+
+func (v dnssecAlgorithmValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnssecAlgorithmValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := powerdns.ValidateAlgorithm(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Algorithm", err.Error())
+	}
+}
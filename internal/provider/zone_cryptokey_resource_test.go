@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPowerdnsZoneCryptokeyResource(t *testing.T) {
+	zoneName := randomZoneName(12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccPowerdnsZoneCryptokeyResourceConfig(zoneName, "key", false),
+				ExpectError: regexp.MustCompile(`Invalid Key Type`),
+			},
+			{
+				Config:      strings.Replace(testAccPowerdnsZoneCryptokeyResourceConfig(zoneName, "ksk", false), `"ECDSAP256SHA256"`, `"13"`, 1),
+				ExpectError: regexp.MustCompile(`Invalid Algorithm`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsZoneCryptokeyResourceConfig(zoneName, "ksk", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "server_id", "localhost"),
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "zone_id", zoneName),
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "keytype", "ksk"),
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "algorithm", "ECDSAP256SHA256"),
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "bits", "256"),
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "active", "false"),
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "published", "true"),
+					resource.TestCheckResourceAttrSet("powerdns_zone_cryptokey.test", "key_id"),
+					resource.TestMatchResourceAttr("powerdns_zone_cryptokey.test", "id", regexp.MustCompile(`^localhost/`+regexp.QuoteMeta(zoneName)+`/\d+$`)),
+					resource.TestCheckResourceAttrSet("powerdns_zone_cryptokey.test", "dnskey"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "powerdns_zone_cryptokey.test",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["powerdns_zone_cryptokey.test"]
+					if !ok {
+						return "", fmt.Errorf("resource powerdns_zone_cryptokey.test not found")
+					}
+					return fmt.Sprintf("localhost/%s/%s", zoneName, rs.Primary.Attributes["key_id"]), nil
+				},
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPowerdnsZoneCryptokeyResourceConfig(zoneName, "ksk", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "keytype", "ksk"),
+					resource.TestCheckResourceAttr("powerdns_zone_cryptokey.test", "active", "true"),
+					resource.TestCheckResourceAttrSet("powerdns_zone_cryptokey.test", "ds.0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsZoneCryptokeyResourceConfig(zoneName, keyType string, active bool) string {
+	return fmt.Sprintf(`
+resource "powerdns_zone" "test" {
+  name = %[1]q
+  server_id = "localhost"
+  kind = "Native"
+}
+
+resource "powerdns_zone_cryptokey" "test" {
+  server_id = powerdns_zone.test.server_id
+  zone_id = powerdns_zone.test.id
+  keytype = %[2]q
+  algorithm = "ECDSAP256SHA256"
+  active = %[3]t
+}
+`, zoneName, keyType, active)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -24,10 +25,19 @@ type ZoneDataSource struct {
 
 // ZoneDataSourceModel describes the data source data model.
 type ZoneDataSourceModel struct {
-	Id       types.String `tfsdk:"id"`
-	ServerId types.String `tfsdk:"server_id"`
-	Name     types.String `tfsdk:"name"`
-	Kind     types.String `tfsdk:"kind"`
+	Id         types.String `tfsdk:"id"`
+	ServerId   types.String `tfsdk:"server_id"`
+	Name       types.String `tfsdk:"name"`
+	Kind       types.String `tfsdk:"kind"`
+	DNSSec     types.Bool   `tfsdk:"dnssec"`
+	Serial     types.Int64  `tfsdk:"serial"`
+	Masters    types.List   `tfsdk:"masters"`
+	SoaEdit    types.String `tfsdk:"soa_edit"`
+	SoaEditAPI types.String `tfsdk:"soa_edit_api"`
+	Account    types.String `tfsdk:"account"`
+	Catalog    types.String `tfsdk:"catalog"`
+	APIRectify types.Bool   `tfsdk:"api_rectify"`
+	Presigned  types.Bool   `tfsdk:"presigned"`
 }
 
 func (d *ZoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -43,10 +53,7 @@ func (d ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 				MarkdownDescription: "Opaque zone id, assigned by the server.",
 				Required:            true,
 			},
-			"server_id": schema.StringAttribute{
-				MarkdownDescription: "The id of the server.",
-				Required:            true,
-			},
+			"server_id": serverIDDataSourceAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the zone (e.g. \"example.com.\") MUST have a trailing dot.",
 				Computed:            true,
@@ -55,6 +62,43 @@ func (d ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest
 				MarkdownDescription: "Zone kind, one of \"Native\", \"Master\", \"Slave\".",
 				Computed:            true,
 			},
+			"dnssec": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not this zone is DNSSEC signed.",
+				Computed:            true,
+			},
+			"serial": schema.Int64Attribute{
+				MarkdownDescription: "The SOA serial number.",
+				Computed:            true,
+			},
+			"masters": schema.ListAttribute{
+				MarkdownDescription: "List of IP addresses configured as a master for this zone (\"Slave\" type zones only).",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"soa_edit": schema.StringAttribute{
+				MarkdownDescription: "The SOA-EDIT metadata item.",
+				Computed:            true,
+			},
+			"soa_edit_api": schema.StringAttribute{
+				MarkdownDescription: "The SOA-EDIT-API metadata item.",
+				Computed:            true,
+			},
+			"account": schema.StringAttribute{
+				MarkdownDescription: "Account owning the zone. Its value is defined by local policy.",
+				Computed:            true,
+			},
+			"catalog": schema.StringAttribute{
+				MarkdownDescription: "The catalog zone this zone is a member of.",
+				Computed:            true,
+			},
+			"api_rectify": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not the zone will be rectified on data changes via the API.",
+				Computed:            true,
+			},
+			"presigned": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not the zone is pre-signed.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -89,6 +133,7 @@ func (d ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
+	data.ServerId = serverIDOrDefault(d.client, data.ServerId)
 	zoneId := data.Id.ValueString()
 	serverId := data.ServerId.ValueString()
 	tflog.Debug(ctx, "Reading zone", map[string]interface{}{
@@ -101,9 +146,19 @@ func (d ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
+	var diags diag.Diagnostics
 	data.Id = types.StringValue(zone.ID)
 	data.Name = types.StringValue(zone.Name)
 	data.Kind = types.StringValue(zone.Kind)
+	data.DNSSec = types.BoolValue(zone.DNSSec)
+	data.Serial = types.Int64Value(zone.Serial)
+	data.Masters, diags = types.ListValueFrom(ctx, types.StringType, zone.Masters)
+	data.SoaEdit = types.StringPointerValue(zone.SoaEdit)
+	data.SoaEditAPI = types.StringPointerValue(zone.SoaEditAPI)
+	data.Account = types.StringPointerValue(zone.Account)
+	data.Catalog = types.StringPointerValue(zone.Catalog)
+	data.APIRectify = types.BoolPointerValue(zone.APIRectify)
+	data.Presigned = types.BoolPointerValue(zone.Presigned)
 
 	tflog.Debug(ctx, "Read zone", map[string]interface{}{
 		"id":        zone.ID,
@@ -112,5 +167,6 @@ func (d ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		"kind":      zone.Kind,
 	})
 
+	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
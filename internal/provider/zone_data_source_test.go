@@ -21,6 +21,8 @@ func TestAccPowerdnsZoneDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.powerdns_zone.test", "server_id", "localhost"),
 					resource.TestCheckResourceAttr("data.powerdns_zone.test", "name", "example.net."),
 					resource.TestCheckResourceAttr("data.powerdns_zone.test", "kind", "Native"),
+					resource.TestCheckResourceAttr("data.powerdns_zone.test", "dnssec", "false"),
+					resource.TestCheckResourceAttrSet("data.powerdns_zone.test", "serial"),
 				),
 			},
 			{
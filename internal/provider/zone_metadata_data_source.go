@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &ZoneMetadataDataSource{}
+
+func NewZoneMetadataDataSource() datasource.DataSource {
+	return &ZoneMetadataDataSource{}
+}
+
+// ZoneMetadataDataSource defines the data source implementation.
+type ZoneMetadataDataSource struct {
+	client *powerdns.Client
+}
+
+// ZoneMetadataDataSourceModel describes the data source data model.
+type ZoneMetadataDataSourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	ServerId types.String `tfsdk:"server_id"`
+	ZoneId   types.String `tfsdk:"zone_id"`
+	Kind     types.String `tfsdk:"kind"`
+	Values   types.List   `tfsdk:"values"`
+}
+
+func (d *ZoneMetadataDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_metadata"
+}
+
+func (d ZoneMetadataDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "PowerDNS Zone Metadata",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "State ID for the metadata (only needed for internal technical purposes).",
+				Computed:            true,
+			},
+			"server_id": serverIDDataSourceAttribute(),
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the zone this metadata belongs to.",
+				Required:            true,
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the metadata (e.g. \"ALLOW-AXFR-FROM\", \"ALSO-NOTIFY\" or \"X-CUSTOM\").",
+				Required:            true,
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "All values of this metadata kind. Empty if the kind is not set on the zone.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ZoneMetadataDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerdns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *powerdns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d ZoneMetadataDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneMetadataDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ServerId = serverIDOrDefault(d.client, data.ServerId)
+	serverId := data.ServerId.ValueString()
+	zoneId := data.ZoneId.ValueString()
+	kind := data.Kind.ValueString()
+	tflog.Debug(ctx, "Reading zone metadata", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"kind":      kind,
+	})
+	metadata, err := d.client.GetMetadata(ctx, serverId, zoneId, kind)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get metadata '%s' of zone '%s': %v", kind, zoneId, err))
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", serverId, zoneId, kind))
+	data.Values, diags = types.ListValueFrom(ctx, types.StringType, nonNilStrings(metadata.Values))
+
+	tflog.Debug(ctx, "Read zone metadata", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"kind":      kind,
+		"values":    metadata.Values,
+	})
+
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
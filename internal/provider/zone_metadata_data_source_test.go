@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsZoneMetadataDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccPowerdnsZoneMetadataDataSourceConfig("example.net.", "localhost", "ALSO-NOTIFY"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerdns_zone_metadata.test", "zone_id", "example.net."),
+					resource.TestCheckResourceAttr("data.powerdns_zone_metadata.test", "server_id", "localhost"),
+					resource.TestCheckResourceAttr("data.powerdns_zone_metadata.test", "kind", "ALSO-NOTIFY"),
+					resource.TestCheckResourceAttr("data.powerdns_zone_metadata.test", "values.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPowerdnsZoneMetadataDataSourceConfig(zoneId, serverId, kind string) string {
+	return fmt.Sprintf(`
+data "powerdns_zone_metadata" "test" {
+  zone_id = %[1]q
+  server_id = %[2]q
+  kind = %[3]q
+}
+`, zoneId, serverId, kind)
+}
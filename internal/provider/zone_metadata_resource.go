@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ZoneMetadataResource{}
+var _ resource.ResourceWithImportState = &ZoneMetadataResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneMetadataResource{}
+
+func NewZoneMetadataResource() resource.Resource {
+	return &ZoneMetadataResource{}
+}
+
+type ZoneMetadataResource struct {
+	client *powerdns.Client
+}
+
+type ZoneMetadataResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	ServerId types.String `tfsdk:"server_id"`
+	ZoneId   types.String `tfsdk:"zone_id"`
+	Kind     types.String `tfsdk:"kind"`
+	Values   types.List   `tfsdk:"values"`
+}
+
+func (r *ZoneMetadataResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_metadata"
+}
+
+func (r *ZoneMetadataResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "PowerDNS Zone Metadata",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "State ID for the metadata (only needed for internal technical purposes).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"server_id": serverIDAttribute(),
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the zone this metadata belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the metadata (e.g. \"ALLOW-AXFR-FROM\", \"ALSO-NOTIFY\" or \"X-CUSTOM\"). Custom kinds must start with \"X-\". Kinds which can't be modified via the API (e.g. \"API-RECTIFY\", \"PRESIGNED\", \"SOA-EDIT-API\") are rejected.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					modifiableMetadataKind(),
+				},
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "All values of this metadata kind. Must contain at least one value, to remove the metadata kind destroy the resource.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listNotEmpty(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ZoneMetadataResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.client, req, resp)
+}
+
+func (r *ZoneMetadataResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*powerdns.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *powerdns.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneMetadataResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneMetadataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneMetadataResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneMetadataResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverId := data.ServerId.ValueString()
+	zoneId := data.ZoneId.ValueString()
+	kind := data.Kind.ValueString()
+	tflog.Debug(ctx, "Reading zone metadata", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"kind":      kind,
+	})
+	metadata, err := r.client.GetMetadata(ctx, serverId, zoneId, kind)
+	// The server answers with an empty list of values for kinds that are not
+	// set on the zone.
+	if powerdns.IsNotFound(err) || (err == nil && len(metadata.Values) == 0) {
+		tflog.Warn(ctx, "Zone metadata not found, removing it from state", map[string]interface{}{
+			"server_id": serverId,
+			"zone_id":   zoneId,
+			"kind":      kind,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get metadata '%s' of zone '%s': %v", kind, zoneId, err))
+		return
+	}
+
+	resp.Diagnostics.Append(zoneMetadataObjectToResourceData(ctx, metadata, &data)...)
+	tflog.Debug(ctx, "Read zone metadata", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"kind":      kind,
+		"values":    metadata.Values,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneMetadataResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ZoneMetadataResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneMetadataResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneMetadataResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverId := data.ServerId.ValueString()
+	zoneId := data.ZoneId.ValueString()
+	kind := data.Kind.ValueString()
+	tflog.Debug(ctx, "Deleting zone metadata", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"kind":      kind,
+	})
+	if err := r.client.DeleteMetadata(ctx, serverId, zoneId, kind); err != nil && !powerdns.IsNotFound(err) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete metadata '%s' of zone '%s': %v", kind, zoneId, err))
+		return
+	}
+	tflog.Debug(ctx, "Deleted zone metadata", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"kind":      kind,
+	})
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ZoneMetadataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	fields, err := parseImportID(req.ID, importServerID(r.client), "server_id", "zone_id", "kind")
+	if err != nil {
+		resp.Diagnostics.AddError("Resource Import ID invalid", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), importZoneID(fields[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kind"), fields[2])...)
+}
+
+// set replaces the values of the metadata kind with the planned ones and
+// updates data with the values stored by the server.
+func (r *ZoneMetadataResource) set(ctx context.Context, data *ZoneMetadataResourceModel, diags *diag.Diagnostics) {
+	metadata := &powerdns.Metadata{Kind: data.Kind.ValueString()}
+	diags.Append(data.Values.ElementsAs(ctx, &metadata.Values, false)...)
+
+	if diags.HasError() {
+		return
+	}
+
+	serverId := data.ServerId.ValueString()
+	zoneId := data.ZoneId.ValueString()
+	tflog.Debug(ctx, "Setting zone metadata", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"kind":      metadata.Kind,
+		"values":    metadata.Values,
+	})
+	metadata, err := r.client.SetMetadata(ctx, serverId, zoneId, metadata)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Unable to set metadata '%s' of zone '%s': %v", data.Kind.ValueString(), zoneId, err))
+		return
+	}
+
+	diags.Append(zoneMetadataObjectToResourceData(ctx, metadata, data)...)
+	tflog.Debug(ctx, "Set zone metadata", map[string]interface{}{
+		"server_id": serverId,
+		"zone_id":   zoneId,
+		"kind":      metadata.Kind,
+		"values":    metadata.Values,
+	})
+}
+
+func zoneMetadataObjectToResourceData(ctx context.Context, metadata *powerdns.Metadata, data *ZoneMetadataResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", data.ServerId.ValueString(), data.ZoneId.ValueString(), data.Kind.ValueString()))
+	data.Values, diags = types.ListValueFrom(ctx, types.StringType, nonNilStrings(metadata.Values))
+
+	return diags
+}
+
+// modifiableMetadataKind returns a validator which rejects metadata kinds that
+// can't be modified via the PowerDNS API, either because they are read-only
+// or because they are neither built-in nor custom "X-" kinds.
+func modifiableMetadataKind() validator.String {
+	return modifiableMetadataKindValidator{}
+}
+
+type modifiableMetadataKindValidator struct{}
+
+func (v modifiableMetadataKindValidator) Description(ctx context.Context) string {
+	return "metadata kind must be modifiable via the PowerDNS API"
+}
+
+func (v modifiableMetadataKindValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v modifiableMetadataKindValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	kind := req.ConfigValue.ValueString()
+	if powerdns.IsReadOnlyMetadataKind(kind) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Read-only Metadata Kind",
+			fmt.Sprintf("Metadata of kind '%s' can't be modified via the PowerDNS API. "+
+				"API-RECTIFY, PRESIGNED and SOA-EDIT-API are managed by the \"api_rectify\", \"presigned\" and \"soa_edit_api\" attributes of powerdns_zone, "+
+				"the other read-only kinds have to be managed with pdnsutil.", kind),
+		)
+		return
+	}
+	if !powerdns.IsValidMetadataKind(kind) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Unknown Metadata Kind",
+			fmt.Sprintf("'%s' is not a metadata kind known to PowerDNS. Custom metadata kinds must start with \"X-\".", kind),
+		)
+	}
+}
+
+// listNotEmpty returns a validator which rejects empty lists.
+func listNotEmpty() validator.List {
+	return listNotEmptyValidator{}
+}
+
+type listNotEmptyValidator struct{}
+
+func (v listNotEmptyValidator) Description(ctx context.Context) string {
+	return "list must contain at least one element"
+}
+
+func (v listNotEmptyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v listNotEmptyValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if len(req.ConfigValue.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Empty List", fmt.Sprintf("%s must contain at least one element.", req.Path))
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPowerdnsZoneMetadataResource(t *testing.T) {
+	zoneName := randomZoneName(12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccPowerdnsZoneMetadataResourceConfig(zoneName, "PRESIGNED", []string{"1"}),
+				ExpectError: regexp.MustCompile(`Metadata of kind 'PRESIGNED' can't be modified via the PowerDNS API`),
+			},
+			{
+				Config:      testAccPowerdnsZoneMetadataResourceConfig(zoneName, "SOA-EDIT-API", []string{"DEFAULT"}),
+				ExpectError: regexp.MustCompile(`Metadata of kind 'SOA-EDIT-API' can't be modified via the PowerDNS API`),
+			},
+			{
+				Config:      testAccPowerdnsZoneMetadataResourceConfig(zoneName, "CUSTOM", []string{"1"}),
+				ExpectError: regexp.MustCompile(`Custom metadata kinds must start with "X-"`),
+			},
+			{
+				Config:      testAccPowerdnsZoneMetadataResourceConfig(zoneName, "ALLOW-AXFR-FROM", []string{}),
+				ExpectError: regexp.MustCompile(`must contain at least one element`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccPowerdnsZoneMetadataResourceConfig(zoneName, "ALLOW-AXFR-FROM", []string{"192.0.2.0/24"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_zone_metadata.test", "id", fmt.Sprintf("localhost/%s/ALLOW-AXFR-FROM", zoneName)),
+					resource.TestCheckResourceAttr("powerdns_zone_metadata.test", "server_id", "localhost"),
+					resource.TestCheckResourceAttr("powerdns_zone_metadata.test", "zone_id", zoneName),
+					resource.TestCheckResourceAttr("powerdns_zone_metadata.test", "kind", "ALLOW-AXFR-FROM"),
+					resource.TestCheckResourceAttr("powerdns_zone_metadata.test", "values.#", "1"),
+					resource.TestCheckResourceAttr("powerdns_zone_metadata.test", "values.0", "192.0.2.0/24"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powerdns_zone_metadata.test",
+				ImportStateId:     fmt.Sprintf("localhost/%s/ALLOW-AXFR-FROM", zoneName),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPowerdnsZoneMetadataResourceConfig(zoneName, "ALLOW-AXFR-FROM", []string{"192.0.2.0/24", "2001:db8::/32"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerdns_zone_metadata.test", "values.#", "2"),
+					resource.TestCheckResourceAttr("powerdns_zone_metadata.test", "values.1", "2001:db8::/32"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPowerdnsZoneMetadataResourceConfig(zoneName, kind string, values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf(`
+resource "powerdns_zone" "test" {
+  name = %[1]q
+  server_id = "localhost"
+  kind = "Native"
+}
+
+resource "powerdns_zone_metadata" "test" {
+  server_id = powerdns_zone.test.server_id
+  zone_id = powerdns_zone.test.id
+  kind = %[2]q
+  values = [%[3]s]
+}
+`, zoneName, kind, strings.Join(quoted, ","))
+}
@@ -3,12 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/gonzolino/terraform-provider-powerdns/internal/powerdns"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -16,6 +21,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &ZoneResource{}
 var _ resource.ResourceWithImportState = &ZoneResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneResource{}
 
 func NewZoneResource() resource.Resource {
 	return &ZoneResource{}
@@ -26,10 +32,20 @@ type ZoneResource struct {
 }
 
 type ZoneResourceModel struct {
-	Id       types.String `tfsdk:"id"`
-	ServerId types.String `tfsdk:"server_id"`
-	Name     types.String `tfsdk:"name"`
-	Kind     types.String `tfsdk:"kind"`
+	Id          types.String `tfsdk:"id"`
+	ServerId    types.String `tfsdk:"server_id"`
+	Name        types.String `tfsdk:"name"`
+	Kind        types.String `tfsdk:"kind"`
+	DNSSec      types.Bool   `tfsdk:"dnssec"`
+	Serial      types.Int64  `tfsdk:"serial"`
+	Masters     types.List   `tfsdk:"masters"`
+	Nameservers types.List   `tfsdk:"nameservers"`
+	SoaEdit     types.String `tfsdk:"soa_edit"`
+	SoaEditAPI  types.String `tfsdk:"soa_edit_api"`
+	Account     types.String `tfsdk:"account"`
+	Catalog     types.String `tfsdk:"catalog"`
+	APIRectify  types.Bool   `tfsdk:"api_rectify"`
+	Presigned   types.Bool   `tfsdk:"presigned"`
 }
 
 func (r *ZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,22 +61,125 @@ func (t *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Opaque zone id, assigned by the server.",
 				Computed:            true,
 			},
-			"server_id": schema.StringAttribute{
-				MarkdownDescription: "The id of the server.",
-				Required:            true,
-			},
+			"server_id": serverIDAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the zone (e.g. \"example.com.\") MUST have a trailing dot.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					fqdn(),
+				},
 			},
 			"kind": schema.StringAttribute{
-				MarkdownDescription: "Zone kind, one of \"Native\", \"Master\", \"Slave\".",
+				MarkdownDescription: "Zone kind, one of \"Native\", \"Master\", \"Slave\", \"Producer\", \"Consumer\".",
 				Required:            true,
+				Validators: []validator.String{
+					zoneKind(),
+				},
+			},
+			"dnssec": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not this zone is DNSSEC signed.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"serial": schema.Int64Attribute{
+				MarkdownDescription: "The SOA serial number.",
+				Computed:            true,
+			},
+			"masters": schema.ListAttribute{
+				MarkdownDescription: "List of IP addresses configured as a master for this zone (\"Slave\" type zones only).",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"nameservers": schema.ListAttribute{
+				MarkdownDescription: "Nameserver names, including the trailing dot, for the zone's NS records. Only used when the zone is created, changing them later only updates the state and shows a warning. Manage the NS record set with `powerdns_recordset` afterwards.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"soa_edit": schema.StringAttribute{
+				MarkdownDescription: "The SOA-EDIT metadata item.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"soa_edit_api": schema.StringAttribute{
+				MarkdownDescription: "The SOA-EDIT-API metadata item.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account": schema.StringAttribute{
+				MarkdownDescription: "Account owning the zone. Its value is defined by local policy.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"catalog": schema.StringAttribute{
+				MarkdownDescription: "The catalog zone this zone is a member of.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"api_rectify": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not the zone will be rectified on data changes via the API.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"presigned": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not the zone is pre-signed. Can only be set when the zone is created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+					boolplanmodifier.RequiresReplace(),
+				},
 			},
 		},
 	}
 }
 
+func (r *ZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planServerID(ctx, r.client, req, resp)
+
+	// The nameservers are only sent when the zone is created, so changing
+	// them afterwards has no effect on the server.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var planned, current types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("nameservers"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("nameservers"), &current)...)
+	if resp.Diagnostics.HasError() || planned.IsUnknown() || planned.Equal(current) {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("nameservers"),
+		"Nameservers Not Updated",
+		"The nameservers of a zone are only used when the zone is created. Changing them doesn't change the NS records of the existing zone, "+
+			"manage the NS record set with powerdns_recordset instead.",
+	)
+}
+
 func (r *ZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -92,7 +211,11 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	zone := &powerdns.Zone{}
-	zoneResourceDataToObject(ctx, data, zone)
+	resp.Diagnostics.Append(zoneResourceDataToObject(ctx, data, zone)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	serverId := data.ServerId.ValueString()
 	tflog.Debug(ctx, "Creating zone", map[string]interface{}{
@@ -105,12 +228,12 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		resp.Diagnostics.AddError(
 			"API Error",
 			fmt.Sprintf("Unable to create zone '%s': %v",
-				zone.Name,
+				data.Name.ValueString(),
 				err))
 		return
 	}
 
-	zoneObjectToResourceData(ctx, zone, &data)
+	resp.Diagnostics.Append(zoneObjectToResourceData(ctx, zone, &data)...)
 	tflog.Debug(ctx, "Created zone", map[string]interface{}{
 		"id":        data.Id.ValueString(),
 		"server_id": serverId,
@@ -146,12 +269,20 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		"server_id": serverId,
 	})
 	zone, err := r.client.GetZone(ctx, serverId, id)
+	if powerdns.IsNotFound(err) {
+		tflog.Warn(ctx, "Zone not found, removing it from state", map[string]interface{}{
+			"id":        id,
+			"server_id": serverId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to get zone '%s': %v", id, err))
 		return
 	}
 
-	zoneObjectToResourceData(ctx, zone, &data)
+	resp.Diagnostics.Append(zoneObjectToResourceData(ctx, zone, &data)...)
 	tflog.Debug(ctx, "Read zone", map[string]interface{}{
 		"id":        data.Id.ValueString(),
 		"server_id": serverId,
@@ -174,7 +305,11 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	zone := &powerdns.Zone{}
-	zoneResourceDataToObject(ctx, data, zone)
+	resp.Diagnostics.Append(zoneResourceDataToObject(ctx, data, zone)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var id string
 	if !data.Id.IsUnknown() && !data.Id.IsNull() {
@@ -186,12 +321,17 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	serverId := data.ServerId.ValueString()
 	tflog.Debug(ctx, "Updating zone", map[string]interface{}{
-		"id":        id,
-		"server_id": serverId,
-		"name":      zone.Name,
-		"kind":      zone.Kind,
-		"dnssec":    zone.DNSSec,
-		"masters":   zone.Masters,
+		"id":           id,
+		"server_id":    serverId,
+		"name":         zone.Name,
+		"kind":         zone.Kind,
+		"dnssec":       zone.DNSSec,
+		"masters":      zone.Masters,
+		"soa_edit":     zone.SoaEdit,
+		"soa_edit_api": zone.SoaEditAPI,
+		"account":      zone.Account,
+		"catalog":      zone.Catalog,
+		"api_rectify":  zone.APIRectify,
 	})
 	if err := r.client.UpdateZone(ctx, serverId, id, zone); err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update zone '%s': %v", id, err))
@@ -212,7 +352,7 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	zoneObjectToResourceData(ctx, zone, &data)
+	resp.Diagnostics.Append(zoneObjectToResourceData(ctx, zone, &data)...)
 	tflog.Debug(ctx, "Read zone", map[string]interface{}{
 		"id":        data.Id.ValueString(),
 		"server_id": serverId,
@@ -240,7 +380,7 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		"id":        zoneId,
 		"server_id": serverId,
 	})
-	if err := r.client.DeleteZone(ctx, serverId, zoneId); err != nil {
+	if err := r.client.DeleteZone(ctx, serverId, zoneId); err != nil && !powerdns.IsNotFound(err) {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete zone '%s': %v", zoneId, err))
 		return
 	}
@@ -253,33 +393,130 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	splittedID := strings.Split(req.ID, "/")
-
-	if len(splittedID) != 2 {
-		resp.Diagnostics.AddError(
-			"Resource Import ID invalid",
-			fmt.Sprintf("ID '%s' should be in format 'server_id/zone_id'", req.ID),
-		)
+	fields, err := parseImportID(req.ID, importServerID(r.client), "server_id", "zone_name")
+	if err != nil {
+		resp.Diagnostics.AddError("Resource Import ID invalid", err.Error())
 		return
 	}
-	serverID := splittedID[0]
-	zoneID := splittedID[1]
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), serverID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), zoneID)...)
 
-	// tfsdk.ResourceImportStateNotImplemented(ctx, "", resp)
-	// tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), importZoneID(fields[1]))...)
 }
 
-func zoneResourceDataToObject(ctx context.Context, data ZoneResourceModel, zone *powerdns.Zone) {
+func zoneResourceDataToObject(ctx context.Context, data ZoneResourceModel, zone *powerdns.Zone) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	zone.ID = data.Id.ValueString()
 	zone.Name = data.Name.ValueString()
 	zone.Kind = data.Kind.ValueString()
+	zone.DNSSec = data.DNSSec.ValueBool()
+	if !data.Masters.IsNull() && !data.Masters.IsUnknown() {
+		diags.Append(data.Masters.ElementsAs(ctx, &zone.Masters, false)...)
+	}
+	if !data.Nameservers.IsNull() && !data.Nameservers.IsUnknown() {
+		diags.Append(data.Nameservers.ElementsAs(ctx, &zone.Nameservers, false)...)
+	}
+	zone.SoaEdit = optionalString(data.SoaEdit)
+	zone.SoaEditAPI = optionalString(data.SoaEditAPI)
+	zone.Account = optionalString(data.Account)
+	zone.Catalog = optionalString(data.Catalog)
+	zone.APIRectify = optionalBool(data.APIRectify)
+	zone.Presigned = optionalBool(data.Presigned)
+
+	return diags
 }
 
-func zoneObjectToResourceData(ctx context.Context, zone *powerdns.Zone, data *ZoneResourceModel) {
+func zoneObjectToResourceData(ctx context.Context, zone *powerdns.Zone, data *ZoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	data.Id = types.StringValue(zone.ID)
 	data.Name = types.StringValue(zone.Name)
 	data.Kind = types.StringValue(zone.Kind)
+	data.DNSSec = types.BoolValue(zone.DNSSec)
+	data.Serial = types.Int64Value(zone.Serial)
+	masters := zone.Masters
+	if masters == nil {
+		masters = []string{}
+	}
+	data.Masters, diags = types.ListValueFrom(ctx, types.StringType, masters)
+	data.SoaEdit = types.StringPointerValue(zone.SoaEdit)
+	data.SoaEditAPI = types.StringPointerValue(zone.SoaEditAPI)
+	data.Account = types.StringPointerValue(zone.Account)
+	data.Catalog = types.StringPointerValue(zone.Catalog)
+	data.APIRectify = types.BoolPointerValue(zone.APIRectify)
+	data.Presigned = types.BoolPointerValue(zone.Presigned)
+	// The server never returns the nameservers of a zone, so data.Nameservers
+	// keeps the configured value.
+
+	return diags
+}
+
+// optionalString returns nil for null or unknown values, so that the
+// attribute is omitted from API requests and the server side value is kept.
+func optionalString(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueStringPointer()
+}
+
+// optionalBool returns nil for null or unknown values, so that the attribute
+// is omitted from API requests and the server side value is kept.
+func optionalBool(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueBoolPointer()
+}
+
+// zoneKind returns a validator which checks that a zone kind is known to
+// PowerDNS.
+func zoneKind() validator.String {
+	return zoneKindValidator{}
+}
+
+type zoneKindValidator struct{}
+
+func (v zoneKindValidator) Description(ctx context.Context) string {
+	return "zone kind must be one of \"Native\", \"Master\", \"Slave\", \"Producer\" or \"Consumer\""
+}
+
+func (v zoneKindValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v zoneKindValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := powerdns.ValidateZoneKind(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Zone Kind", err.Error())
+	}
+}
+
+// fqdn returns a validator which checks that a name is a fully qualified
+// domain name with a trailing dot.
+func fqdn() validator.String {
+	return fqdnValidator{}
+}
+
+type fqdnValidator struct{}
+
+func (v fqdnValidator) Description(ctx context.Context) string {
+	return "name must be a fully qualified domain name with a trailing dot"
+}
+
+func (v fqdnValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v fqdnValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := powerdns.ValidateFQDN(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Domain Name", err.Error())
+	}
 }
@@ -1,8 +1,11 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -15,14 +18,28 @@ func TestAccPowerdnsZoneResource(t *testing.T) {
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccPowerdnsZoneResourceConfig(zoneName, "localhost", "Primary", "alice"),
+				ExpectError: regexp.MustCompile(`not a valid zone kind`),
+			},
+			{
+				Config:      testAccPowerdnsZoneResourceConfig(strings.TrimSuffix(zoneName, "."), "localhost", "Native", "alice"),
+				ExpectError: regexp.MustCompile(`must be fully qualified and end with a dot`),
+			},
 			// Create and Read testing
 			{
-				Config: testAccPowerdnsZoneResourceConfig(zoneName, "localhost", "Native"),
+				Config: testAccPowerdnsZoneResourceConfig(zoneName, "localhost", "Native", "alice"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("powerdns_zone.test", "id", zoneName),
 					resource.TestCheckResourceAttr("powerdns_zone.test", "server_id", "localhost"),
 					resource.TestCheckResourceAttr("powerdns_zone.test", "name", zoneName),
 					resource.TestCheckResourceAttr("powerdns_zone.test", "kind", "Native"),
+					resource.TestCheckResourceAttr("powerdns_zone.test", "account", "alice"),
+					resource.TestCheckResourceAttr("powerdns_zone.test", "soa_edit_api", "INCEPTION-INCREMENT"),
+					resource.TestCheckResourceAttr("powerdns_zone.test", "dnssec", "false"),
+					resource.TestCheckResourceAttr("powerdns_zone.test", "masters.#", "0"),
+					resource.TestCheckResourceAttrSet("powerdns_zone.test", "serial"),
 				),
 			},
 			// ImportState testing
@@ -31,30 +48,57 @@ func TestAccPowerdnsZoneResource(t *testing.T) {
 				ImportStateId:     "localhost/" + zoneName,
 				ImportState:       true,
 				ImportStateVerify: true,
+				// The server never returns the nameservers of a zone.
+				ImportStateVerifyIgnore: []string{"nameservers"},
+			},
+			// ImportState testing by zone name without server_id
+			{
+				ResourceName:      "powerdns_zone.test",
+				ImportStateId:     strings.TrimSuffix(zoneName, "."),
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The server never returns the nameservers of a zone.
+				ImportStateVerifyIgnore: []string{"nameservers"},
 			},
 			// Update and Read testing
 			{
-				Config: testAccPowerdnsZoneResourceConfig(zoneName, "localhost", "Master"),
+				Config: testAccPowerdnsZoneResourceConfig(zoneName, "localhost", "Master", "bob"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("powerdns_zone.test", "id", zoneName),
 					resource.TestCheckResourceAttr("powerdns_zone.test", "server_id", "localhost"),
 					resource.TestCheckResourceAttr("powerdns_zone.test", "name", zoneName),
 					resource.TestCheckResourceAttr("powerdns_zone.test", "kind", "Master"),
+					resource.TestCheckResourceAttr("powerdns_zone.test", "account", "bob"),
+					resource.TestCheckResourceAttr("powerdns_zone.test", "soa_edit_api", "INCEPTION-INCREMENT"),
 				),
 			},
+			// Out-of-band deletion testing
+			{
+				PreConfig: func() {
+					if err := testAccClient(t).DeleteZone(context.Background(), "localhost", zoneName); err != nil {
+						t.Fatalf("unable to delete zone '%s': %v", zoneName, err)
+					}
+				},
+				Config:             testAccPowerdnsZoneResourceConfig(zoneName, "localhost", "Master", "bob"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
-func testAccPowerdnsZoneResourceConfig(name, serverId, kind string) string {
+func testAccPowerdnsZoneResourceConfig(name, serverId, kind, account string) string {
 	return fmt.Sprintf(`
 resource "powerdns_zone" "test" {
   name = %[1]q
   server_id = %[2]q
   kind = %[3]q
+  account = %[4]q
+  soa_edit_api = "INCEPTION-INCREMENT"
+  nameservers = ["ns1.example.net."]
 }
-`, name, serverId, kind)
+`, name, serverId, kind, account)
 }
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyz"